@@ -44,7 +44,7 @@ func (a *AnySchema) Transform(f func(*Context)) *AnySchema {
 }
 
 // Required mark a key as required which will not allow undefined or null as value.
-// All keys are optional by default.
+// All keys are optional by default. PatchMode skips the absent keys, but still rejects a present null.
 func (a *AnySchema) Required() *AnySchema {
 	a.required = boolPtr(true)
 	return a.PrependTransform(func(ctx *Context) {
@@ -67,10 +67,15 @@ func (a *AnySchema) Optional() *AnySchema {
 }
 
 // Default set a default value if the original value is undefined or null.
+// No default value is set in PatchMode.
 func (a *AnySchema) Default(value interface{}) *AnySchema {
 	a.required = boolPtr(false)
 	return a.PrependTransform(func(ctx *Context) {
 		if ctx.Value == nil {
+			if ctx.options.partial {
+				ctx.Skip()
+				return
+			}
 			ctx.Value = value
 		}
 	})
@@ -93,6 +98,13 @@ func (a *AnySchema) Equal(value interface{}) *AnySchema {
 	})
 }
 
+// Strip validate the key and then remove it from the parent object.
+// Useful for keys like `confirm_password` that are only needed during validation.
+func (a *AnySchema) Strip() *AnySchema {
+	a.strip = true
+	return a
+}
+
 // When add a conditional schema based on another key value
 // The reference path support use `.` access object property, just like javascript.
 // The condition can be a Schema or value.
@@ -1,9 +1,14 @@
 package jio
 
 import (
+    "encoding/json"
     "errors"
     "fmt"
+    "math/big"
     "reflect"
+    "sort"
+    "strconv"
+    "strings"
 )
 
 var _ Schema = new(ArraySchema)
@@ -21,6 +26,11 @@ type ArraySchema struct {
 
 	required *bool
 	rules    []func(*Context)
+	rest     Schema
+	single   bool
+	drop     bool
+	split    *string
+	json     bool
 }
 
 // SetPriority same as AnySchema.SetPriority
@@ -73,11 +83,21 @@ func (a *ArraySchema) Default(value interface{}) *ArraySchema {
 	a.required = boolPtr(false)
 	return a.PrependTransform(func(ctx *Context) {
 		if ctx.Value == nil {
+			if ctx.options.partial {
+				ctx.Skip()
+				return
+			}
 			ctx.Value = value
 		}
 	})
 }
 
+// Strip same as AnySchema.Strip
+func (a *ArraySchema) Strip() *ArraySchema {
+	a.strip = true
+	return a
+}
+
 // When same as AnySchema.When
 func (a *ArraySchema) When(refPath string, condition interface{}, then Schema) *ArraySchema {
 	return a.Transform(func(ctx *Context) { a.whenEqual(ctx, refPath, condition, then) })
@@ -104,27 +124,160 @@ func (a *ArraySchema) Check(f func(*Context) error) *ArraySchema {
 	})
 }
 
-// Items check if this value can pass the validation of any schema.
+// Items check if each item can pass the validation of any schema.
+// The item is replaced by the value of the first schema it passes.
+// When no schema matches, the errors of all schemas are reported at the item's index.
 func (a *ArraySchema) Items(schemas ...Schema) *ArraySchema {
 	return a.Check(func(ctx *Context) error {
 		ctxRV := reflect.ValueOf(ctx.Value)
 		errs := NewErrorBag()
+		var dropped []int
 		for i := 0; i < ctxRV.Len(); i++ {
 			rv := ctxRV.Index(i).Interface()
+			itemErrs := NewErrorBag()
 			for _, schema := range schemas {
-				ctxNew := NewContext(rv)
-				ctxNew.root = ctx.root
-				ctxNew.parentRoot = ctx.parentRoot
-				ctxNew.parent = ctx.parent
-				ctxNew.fields = append(ctxNew.fields, append(ctx.fields, fmt.Sprintf(`%d`, i))...)
+				item := rv
+				if len(schemas) > 1 {
+					item = deepCopy(rv)
+				}
+				ctxNew := ctx.fork(strconv.Itoa(i), item)
 				schema.Validate(ctxNew)
-				errs.AddBag(ctxNew.ErrorBag)
+				if ctxNew.ErrorBag.Empty() {
+					setItem(ctx, i, ctxNew.Value)
+					itemErrs = nil
+					break
+				}
+				itemErrs.AddBag(ctxNew.ErrorBag)
+			}
+			if itemErrs != nil {
+				if a.drop {
+					ctx.Warnings.Add(ErrorArrayItemDropped(ctx.fork(strconv.Itoa(i), nil), itemErrs.StringArray()))
+					dropped = append(dropped, i)
+					continue
+				}
+				errs.AddBag(itemErrs)
+			}
+		}
+		if len(dropped) > 0 {
+			ctx.Value = removeItems(ctxRV, dropped)
+		}
+		return errs
+	})
+}
+
+// DropInvalid remove the items which fail the Items schemas instead of throwing an error.
+// Every removed item is reported to the context Warnings with its original index.
+func (a *ArraySchema) DropInvalid() *ArraySchema {
+	a.drop = true
+	return a
+}
+
+// removeItems return a new slice of the same type without the items at the sorted indexes.
+func removeItems(ctxRV reflect.Value, indexes []int) interface{} {
+	items := reflect.MakeSlice(ctxRV.Type(), 0, ctxRV.Len()-len(indexes))
+	for i := 0; i < ctxRV.Len(); i++ {
+		if len(indexes) > 0 && indexes[0] == i {
+			indexes = indexes[1:]
+			continue
+		}
+		items = reflect.Append(items, ctxRV.Index(i))
+	}
+	return items.Interface()
+}
+
+// Ordered check each item using the schema at the same position, like a tuple.
+// Missing items are validated as undefined, so they can be marked as Required.
+// Items beyond the ordered schemas are validated with the Rest schema,
+// and an error is thrown if there is no Rest schema.
+func (a *ArraySchema) Ordered(schemas ...Schema) *ArraySchema {
+	return a.Check(func(ctx *Context) error {
+		ctxRV := reflect.ValueOf(ctx.Value)
+		errs := NewErrorBag()
+		for i, schema := range schemas {
+			var item interface{}
+			if i < ctxRV.Len() {
+				item = ctxRV.Index(i).Interface()
+			}
+			ctxNew := ctx.fork(strconv.Itoa(i), item)
+			schema.Validate(ctxNew)
+			errs.AddBag(ctxNew.ErrorBag)
+			if ctxNew.ErrorBag.Empty() && i < ctxRV.Len() {
+				setItem(ctx, i, ctxNew.Value)
+			}
+		}
+		if ctxRV.Len() > len(schemas) && a.rest == nil {
+			errs.Add(ErrorArrayLengthMax(ctx, len(schemas)))
+			return errs
+		}
+		for i := len(schemas); i < ctxRV.Len(); i++ {
+			ctxNew := ctx.fork(strconv.Itoa(i), ctxRV.Index(i).Interface())
+			a.rest.Validate(ctxNew)
+			errs.AddBag(ctxNew.ErrorBag)
+			if ctxNew.ErrorBag.Empty() {
+				setItem(ctx, i, ctxNew.Value)
 			}
 		}
 		return errs
 	})
 }
 
+// Tuple same as Ordered.
+func (a *ArraySchema) Tuple(schemas ...Schema) *ArraySchema {
+	return a.Ordered(schemas...)
+}
+
+// Rest set the schema of the items beyond the schemas of Ordered.
+func (a *ArraySchema) Rest(schema Schema) *ArraySchema {
+	a.rest = schema
+	return a
+}
+
+// setItem replace the item at index i if the value is a []interface{}.
+func setItem(ctx *Context, i int, value interface{}) {
+	if ctxValue, ok := ctx.Value.([]interface{}); ok {
+		ctxValue[i] = value
+	}
+}
+
+// Has check if at least one item can pass the validation of the schema.
+// Items are validated on a copy, so the schema never changes them.
+func (a *ArraySchema) Has(schema Schema) *ArraySchema {
+	return a.MinContains(1, schema)
+}
+
+// MinContains check if at least `min` items can pass the validation of the schema.
+func (a *ArraySchema) MinContains(min int, schema Schema) *ArraySchema {
+	return a.Check(func(ctx *Context) error {
+		if matched := countContains(ctx, schema); matched < min {
+			return errors.New(ErrorMessageArrayContainsMin(min, matched))
+		}
+		return nil
+	})
+}
+
+// MaxContains check if at most `max` items can pass the validation of the schema.
+func (a *ArraySchema) MaxContains(max int, schema Schema) *ArraySchema {
+	return a.Check(func(ctx *Context) error {
+		if matched := countContains(ctx, schema); matched > max {
+			return errors.New(ErrorMessageArrayContainsMax(max, matched))
+		}
+		return nil
+	})
+}
+
+func countContains(ctx *Context, schema Schema) int {
+	ctxRV := reflect.ValueOf(ctx.Value)
+	var matched int
+	for i := 0; i < ctxRV.Len(); i++ {
+		ctxNew := ctx.fork(strconv.Itoa(i), deepCopy(ctxRV.Index(i).Interface()))
+		schema.Validate(ctxNew)
+		if ctxNew.ErrorBag.Empty() {
+			matched++
+		}
+	}
+	return matched
+}
+
 // Min check if the length of this slice is greater than or equal to the provided length.
 func (a *ArraySchema) Min(min int) *ArraySchema {
 	return a.Check(func(ctx *Context) error {
@@ -155,7 +308,319 @@ func (a *ArraySchema) Length(length int) *ArraySchema {
 	})
 }
 
+// UniqueOptions controls how Unique compares the items.
+type UniqueOptions struct {
+	// Paths compare the items by the values at these field paths instead of the whole item.
+	// The paths support `.` to access nested object properties, same as Context.Ref.
+	Paths []string
+	// IgnoreCase compare strings case-insensitively.
+	IgnoreCase bool
+}
+
+// Unique check that all items are unique using deep equality.
+// Works on scalars, objects and nested arrays, and numbers are compared by value regardless of their type.
+// Each duplicate item is reported at its own index with the index of the first equal item.
+func (a *ArraySchema) Unique(options ...UniqueOptions) *ArraySchema {
+	var opts UniqueOptions
+	if len(options) > 0 {
+		opts = options[0]
+	}
+	return a.Check(func(ctx *Context) error {
+		ctxRV := reflect.ValueOf(ctx.Value)
+		errs := NewErrorBag()
+		seen := make(map[string]int, ctxRV.Len())
+		for i := 0; i < ctxRV.Len(); i++ {
+			key := uniqueKey(ctxRV.Index(i).Interface(), opts)
+			if first, ok := seen[key]; ok {
+				errs.Add(ErrorArrayUnique(ctx.fork(strconv.Itoa(i), nil), first, opts.Paths))
+				continue
+			}
+			seen[key] = i
+		}
+		return errs
+	})
+}
+
+func uniqueKey(item interface{}, opts UniqueOptions) string {
+	var value interface{} = item
+	if len(opts.Paths) > 0 {
+		values := make([]interface{}, len(opts.Paths))
+		for j, path := range opts.Paths {
+			values[j], _ = ref(item, strings.Split(path, "."))
+		}
+		value = values
+	}
+	if opts.IgnoreCase {
+		value = lowerStrings(value)
+	}
+	key, err := json.Marshal(exactNumbers(value))
+	if err != nil {
+		return fmt.Sprintf("%#v", value)
+	}
+	return string(key)
+}
+
+// lowerStrings return a copy of the value with all strings converted to lowercase.
+func lowerStrings(value interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		return strings.ToLower(v)
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			m[key] = lowerStrings(item)
+		}
+		return m
+	case []interface{}:
+		s := make([]interface{}, len(v))
+		for i, item := range v {
+			s[i] = lowerStrings(item)
+		}
+		return s
+	default:
+		return value
+	}
+}
+
+// exactNumbers return a copy of the value with all json.Number in a canonical form,
+// so the numbers equal by value have the same json encoding. The numbers which are not
+// exact float64 are replaced with their exact fraction in a tagged string like "\x00number 1/10".
+func exactNumbers(value interface{}) interface{} {
+	switch v := value.(type) {
+	case json.Number:
+		exact, ok := new(big.Rat).SetString(string(v))
+		if !ok {
+			return value
+		}
+		if f, exactFloat := exact.Float64(); exactFloat {
+			return f
+		}
+		return "\x00number " + exact.RatString()
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			m[key] = exactNumbers(item)
+		}
+		return m
+	case []interface{}:
+		s := make([]interface{}, len(v))
+		for i, item := range v {
+			s[i] = exactNumbers(item)
+		}
+		return s
+	default:
+		return value
+	}
+}
+
+// Dedupe remove the duplicate items, keeping the first one.
+// Items are compared the same way as Unique.
+func (a *ArraySchema) Dedupe(options ...UniqueOptions) *ArraySchema {
+	var opts UniqueOptions
+	if len(options) > 0 {
+		opts = options[0]
+	}
+	return a.Transform(func(ctx *Context) {
+		if !ctx.AssertKind(reflect.Slice) {
+			ctx.Abort(ErrorTypeArray(ctx))
+			return
+		}
+		ctxRV := reflect.ValueOf(ctx.Value)
+		seen := make(map[string]bool, ctxRV.Len())
+		var duplicates []int
+		for i := 0; i < ctxRV.Len(); i++ {
+			key := uniqueKey(ctxRV.Index(i).Interface(), opts)
+			if seen[key] {
+				duplicates = append(duplicates, i)
+				continue
+			}
+			seen[key] = true
+		}
+		if len(duplicates) > 0 {
+			ctx.Value = removeItems(ctxRV, duplicates)
+		}
+	})
+}
+
+// SortOptions controls how Sort orders the items.
+type SortOptions struct {
+	// Paths order the items by the values at these field paths instead of the whole item.
+	// Later paths are used when the values of the previous paths are equal.
+	Paths []string
+	// Descending reverse the order.
+	Descending bool
+}
+
+// Sort order the items in place using a stable sort.
+// Undefined values come first, followed by booleans, numbers, strings and other values,
+// values of the same type are compared by their natural order.
+func (a *ArraySchema) Sort(options ...SortOptions) *ArraySchema {
+	var opts SortOptions
+	if len(options) > 0 {
+		opts = options[0]
+	}
+	return a.Transform(func(ctx *Context) {
+		if !ctx.AssertKind(reflect.Slice) {
+			ctx.Abort(ErrorTypeArray(ctx))
+			return
+		}
+		ctxRV := reflect.ValueOf(ctx.Value)
+		keys := make([][]interface{}, ctxRV.Len())
+		order := make([]int, ctxRV.Len())
+		for i := range keys {
+			item := ctxRV.Index(i).Interface()
+			order[i] = i
+			if len(opts.Paths) == 0 {
+				keys[i] = []interface{}{item}
+				continue
+			}
+			for _, path := range opts.Paths {
+				value, _ := ref(item, strings.Split(path, "."))
+				keys[i] = append(keys[i], value)
+			}
+		}
+		sort.SliceStable(order, func(i, j int) bool {
+			for k := range keys[order[i]] {
+				if c := compareValues(keys[order[i]][k], keys[order[j]][k]); c != 0 {
+					return (c < 0) != opts.Descending
+				}
+			}
+			return false
+		})
+		items := reflect.MakeSlice(ctxRV.Type(), 0, ctxRV.Len())
+		for _, i := range order {
+			items = reflect.Append(items, ctxRV.Index(i))
+		}
+		reflect.Copy(ctxRV, items)
+	})
+}
+
+// compareValues return -1, 0 or 1 comparing two json values.
+func compareValues(a, b interface{}) int {
+	rankA, rankB := valueRank(a), valueRank(b)
+	if rankA != rankB {
+		return compareInts(rankA, rankB)
+	}
+	switch rankA {
+	case 1:
+		return compareInts(boolInt(a.(bool)), boolInt(b.(bool)))
+	case 2:
+		numberA, okA := a.(json.Number)
+		numberB, okB := b.(json.Number)
+		if okA && okB {
+			x, okX := new(big.Rat).SetString(string(numberA))
+			y, okY := new(big.Rat).SetString(string(numberB))
+			if okX && okY {
+				return x.Cmp(y)
+			}
+		}
+		if okA {
+			return compareNumber(a, numberFloat(b))
+		}
+		if okB {
+			return -compareNumber(b, numberFloat(a))
+		}
+		x, y := numberFloat(a), numberFloat(b)
+		if x < y {
+			return -1
+		} else if x > y {
+			return 1
+		}
+		return 0
+	case 3:
+		return strings.Compare(a.(string), b.(string))
+	}
+	return 0
+}
+
+var float64Type = reflect.TypeOf(float64(0))
+
+// numberFloat return the float64 value of any go number or json.Number.
+func numberFloat(value interface{}) float64 {
+	if f, ok := numberValue(value); ok {
+		return f
+	}
+	return reflect.ValueOf(value).Convert(float64Type).Float()
+}
+
+func valueRank(value interface{}) int {
+	if value == nil {
+		return 0
+	}
+	if _, ok := value.(json.Number); ok {
+		return 2
+	}
+	switch reflect.TypeOf(value).Kind() {
+	case reflect.Bool:
+		return 1
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return 2
+	case reflect.String:
+		return 3
+	}
+	return 4
+}
+
+func compareInts(a, b int) int {
+	if a < b {
+		return -1
+	} else if a > b {
+		return 1
+	}
+	return 0
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// ParseString split a string value into an array of strings before validation,
+// for example `1,2,3` becomes ["1", "2", "3"] with the separator `,`.
+// Whitespace around the items is trimmed and an empty string becomes an empty array.
+func (a *ArraySchema) ParseString(separator string) *ArraySchema {
+	a.split = &separator
+	return a
+}
+
+// ParseJSON decode a string value containing a JSON array before validation.
+// When ParseString is also used, only strings starting with `[` are decoded as JSON.
+func (a *ArraySchema) ParseJSON() *ArraySchema {
+	a.json = true
+	return a
+}
+
+func (a *ArraySchema) parseString(ctxValue string) (interface{}, bool) {
+	if a.json && (a.split == nil || strings.HasPrefix(strings.TrimSpace(ctxValue), "[")) {
+		var items []interface{}
+		if err := json.Unmarshal([]byte(ctxValue), &items); err != nil {
+			return nil, false
+		}
+		return items, true
+	}
+	items := make([]interface{}, 0)
+	if strings.TrimSpace(ctxValue) == "" {
+		return items, true
+	}
+	for _, item := range strings.Split(ctxValue, *a.split) {
+		items = append(items, strings.TrimSpace(item))
+	}
+	return items, true
+}
+
+// Single wrap a value which is not an array into a single item array before validation.
+func (a *ArraySchema) Single() *ArraySchema {
+	a.single = true
+	return a
+}
+
 // UniqueObjects checks that all slice objects are unique, using a concatenation of all field values as the composite key for each object.
+//
+// Deprecated: use Unique with UniqueOptions.Paths, which compares field values of any type.
 func (a *ArraySchema) UniqueObjects(fields ...string) *ArraySchema {
     return a.Check(func(ctx *Context) error {
         ref := reflect.ValueOf(ctx.Value)
@@ -187,6 +652,23 @@ func (a *ArraySchema) UniqueObjects(fields ...string) *ArraySchema {
 
 // Validate same as AnySchema.Validate
 func (a *ArraySchema) Validate(ctx *Context) {
+    if ctxValue, ok := ctx.Value.(string); ok && (a.split != nil || a.json) {
+        items, ok := a.parseString(ctxValue)
+        if !ok {
+            ctx.Abort(ErrorTypeArray(ctx))
+            return
+        }
+        ctx.Value = items
+    } else if ok && ctx.options.coerce {
+        if ctxValue == "" {
+            ctx.Value = []interface{}{}
+        } else {
+            ctx.Value = []interface{}{ctxValue}
+        }
+    }
+    if ctx.Value != nil && a.single && !ctx.AssertKind(reflect.Slice) {
+        ctx.Value = []interface{}{ctx.Value}
+    }
     if ctx.Value != nil {
         if !ctx.AssertKind(reflect.Slice) {
             ctx.Abort(ErrorTypeArray(ctx))
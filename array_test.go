@@ -197,3 +197,239 @@ func TestArraySchema_Validate(t *testing.T) {
 		t.Error("not array")
 	}
 }
+
+func TestArraySchema_ItemsAnyOf(t *testing.T) {
+	schema := Array().Items(Number().Integer(), String().Uppercase())
+	value := []interface{}{"a", 1.0}
+	ctx := NewContext(value)
+	schema.Validate(ctx)
+	if !ctx.ErrorBag.Empty() {
+		t.Error("item should match any schema", ctx.ErrorBag)
+	}
+	if value[0] != "A" {
+		t.Error("item should be transformed")
+	}
+
+	ctx = NewContext([]interface{}{"a", 1.5})
+	schema.Validate(ctx)
+	if ctx.ErrorBag.Empty() || ctx.ErrorBag.StringArray()[0][:2] != "1 " {
+		t.Error("error should report the item index", ctx.ErrorBag)
+	}
+}
+
+func TestArraySchema_Ordered(t *testing.T) {
+	schema := Array().Ordered(Number().Min(-180).Max(180).Required(), Number().Min(-90).Max(90).Required())
+	ctx := NewContext([]interface{}{120.5, 30.0})
+	schema.Validate(ctx)
+	if !ctx.ErrorBag.Empty() {
+		t.Error("ordered test failed", ctx.ErrorBag)
+	}
+
+	ctx = NewContext([]interface{}{120.5, 100.0})
+	schema.Validate(ctx)
+	if ctx.ErrorBag.Error() != "[1 must be <= 90]" {
+		t.Error("error should report the item index", ctx.ErrorBag)
+	}
+
+	ctx = NewContext([]interface{}{120.5})
+	schema.Validate(ctx)
+	if ctx.ErrorBag.Error() != "[1 is required]" {
+		t.Error("missing item should be required", ctx.ErrorBag)
+	}
+
+	ctx = NewContext([]interface{}{120.5, 30.0, 1.0})
+	schema.Validate(ctx)
+	if ctx.ErrorBag.Empty() {
+		t.Error("extra items should fail without rest schema")
+	}
+}
+
+func TestArraySchema_TupleRest(t *testing.T) {
+	schema := Array().Tuple(String().Required(), Number().Integer()).Rest(Bool())
+	ctx := NewContext([]interface{}{"faceair", 18.0, true, false})
+	schema.Validate(ctx)
+	if !ctx.ErrorBag.Empty() {
+		t.Error("rest test failed", ctx.ErrorBag)
+	}
+
+	ctx = NewContext([]interface{}{"faceair", 18.0, true, "no"})
+	schema.Validate(ctx)
+	if ctx.ErrorBag.Error() != "[3 must be a boolean]" {
+		t.Error("rest item error should report the index", ctx.ErrorBag)
+	}
+}
+
+func TestArraySchema_Has(t *testing.T) {
+	admin := Object().Keys(K{"role": String().Lowercase().Equal("admin").Required()})
+	schema := Array().Has(admin)
+
+	value := []interface{}{
+		map[string]interface{}{"role": "user"},
+		map[string]interface{}{"role": "ADMIN"},
+	}
+	ctx := NewContext(value)
+	schema.Validate(ctx)
+	if !ctx.ErrorBag.Empty() {
+		t.Error("has test failed", ctx.ErrorBag)
+	}
+	if value[1].(map[string]interface{})["role"] != "ADMIN" {
+		t.Error("has should not change items")
+	}
+
+	ctx = NewContext([]interface{}{map[string]interface{}{"role": "user"}})
+	schema.Validate(ctx)
+	if ctx.ErrorBag.Error() != "[ must contain at least 1 item matching the schema [matched: 0]]" {
+		t.Error("error should report the matched count", ctx.ErrorBag)
+	}
+}
+
+func TestArraySchema_MinMaxContains(t *testing.T) {
+	primary := Object().Keys(K{"primary": Bool().Equal(true).Required()})
+	schema := Array().MinContains(1, primary).MaxContains(2, primary)
+	item := map[string]interface{}{"primary": true}
+
+	ctx := NewContext([]interface{}{item, item})
+	schema.Validate(ctx)
+	if !ctx.ErrorBag.Empty() {
+		t.Error("contains test failed", ctx.ErrorBag)
+	}
+
+	ctx = NewContext([]interface{}{item, item, item})
+	schema.Validate(ctx)
+	if ctx.ErrorBag.Error() != "[ cannot contain more than 2 items matching the schema [matched: 3]]" {
+		t.Error("max contains should fail", ctx.ErrorBag)
+	}
+}
+
+func TestArraySchema_Unique(t *testing.T) {
+	schema := Array().Unique()
+	ctx := NewContext([]interface{}{1, 2.0, "1", []interface{}{"a", "bc"}, []interface{}{"ab", "c"}})
+	schema.Validate(ctx)
+	if !ctx.ErrorBag.Empty() {
+		t.Error("unique test failed", ctx.ErrorBag)
+	}
+
+	ctx = NewContext([]interface{}{1, 2.0, 1.0, map[string]interface{}{"a": 1}, map[string]interface{}{"a": 1.0}})
+	schema.Validate(ctx)
+	if ctx.ErrorBag.Error() != "[2 must be unique [duplicate of 0]; 4 must be unique [duplicate of 3]]" {
+		t.Error("duplicates should be reported with indexes", ctx.ErrorBag)
+	}
+
+	schema = Array().Unique(UniqueOptions{Paths: []string{"id", "owner.name"}, IgnoreCase: true})
+	ctx = NewContext([]interface{}{
+		map[string]interface{}{"id": 1.0, "owner": map[string]interface{}{"name": "Faceair"}},
+		map[string]interface{}{"id": 2.0, "owner": map[string]interface{}{"name": "faceair"}},
+		map[string]interface{}{"id": 1.0, "owner": map[string]interface{}{"name": "FACEAIR"}},
+	})
+	schema.Validate(ctx)
+	if ctx.ErrorBag.Error() != "[2 must be unique [duplicate of 0, uniqueness fields: id, owner.name]]" {
+		t.Error("duplicates by paths should be reported", ctx.ErrorBag)
+	}
+}
+
+func TestArraySchema_Single(t *testing.T) {
+	schema := Array().Single().Items(String()).Min(1)
+	ctx := NewContext("tag")
+	schema.Validate(ctx)
+	if !ctx.ErrorBag.Empty() || !reflect.DeepEqual(ctx.Value, []interface{}{"tag"}) {
+		t.Error("single value should be wrapped", ctx.ErrorBag)
+	}
+
+	ctx = NewContext([]interface{}{"a", "b"})
+	schema.Validate(ctx)
+	if !ctx.ErrorBag.Empty() || reflect.ValueOf(ctx.Value).Len() != 2 {
+		t.Error("array should not be wrapped")
+	}
+}
+
+func TestArraySchema_Dedupe(t *testing.T) {
+	ctx := NewContext([]interface{}{"a", "B", "b", "a"})
+	Array().Dedupe(UniqueOptions{IgnoreCase: true}).Validate(ctx)
+	if !reflect.DeepEqual(ctx.Value, []interface{}{"a", "B"}) {
+		t.Error("dedupe test failed", ctx.Value)
+	}
+
+	ctx = NewContext([]int{1, 2, 1})
+	Array().Dedupe().Validate(ctx)
+	if !reflect.DeepEqual(ctx.Value, []int{1, 2}) {
+		t.Error("dedupe should keep the slice type", ctx.Value)
+	}
+}
+
+func TestArraySchema_Sort(t *testing.T) {
+	ctx := NewContext([]interface{}{"b", 2.0, nil, "a", 1, true})
+	Array().Sort().Validate(ctx)
+	if !reflect.DeepEqual(ctx.Value, []interface{}{nil, true, 1, 2.0, "a", "b"}) {
+		t.Error("sort test failed", ctx.Value)
+	}
+
+	a := map[string]interface{}{"name": "a", "age": 20.0}
+	b := map[string]interface{}{"name": "b", "age": 30.0}
+	c := map[string]interface{}{"name": "c", "age": 20.0}
+	ctx = NewContext([]interface{}{c, a, b})
+	Array().Sort(SortOptions{Paths: []string{"age", "name"}, Descending: true}).Validate(ctx)
+	if !reflect.DeepEqual(ctx.Value, []interface{}{b, c, a}) {
+		t.Error("sort by paths test failed", ctx.Value)
+	}
+}
+
+func TestArraySchema_DropInvalid(t *testing.T) {
+	warnings := NewErrorBag()
+	ctx := NewContext(map[string]interface{}{
+		"tags": []interface{}{"a", 1.0, "b", true},
+	}, CollectWarnings(warnings))
+	Object().Keys(K{
+		"tags": Array().Items(String()).DropInvalid(),
+	}).Validate(ctx)
+	if !ctx.ErrorBag.Empty() {
+		t.Error("invalid items should not error", ctx.ErrorBag)
+	}
+	if !reflect.DeepEqual(ctx.Value.(map[string]interface{})["tags"], []interface{}{"a", "b"}) {
+		t.Error("invalid items should be dropped", ctx.Value)
+	}
+	if len(warnings.StringArray()) != 2 || warnings.StringArray()[0] != "tags.1 was removed because it is invalid [tags.1 must be a string]" {
+		t.Error("dropped items should be reported", warnings)
+	}
+}
+
+func TestArraySchema_ParseString(t *testing.T) {
+	schema := Array().ParseString(",").Items(Number().ParseString().Integer())
+	ctx := NewContext("1, 2,3")
+	schema.Validate(ctx)
+	if !ctx.ErrorBag.Empty() || !reflect.DeepEqual(ctx.Value, []interface{}{1.0, 2.0, 3.0}) {
+		t.Error("parse string test failed", ctx.ErrorBag, ctx.Value)
+	}
+
+	ctx = NewContext("1,a")
+	schema.Validate(ctx)
+	if ctx.ErrorBag.Error() != "[1 must be a number]" {
+		t.Error("invalid item should fail", ctx.ErrorBag)
+	}
+
+	ctx = NewContext("")
+	schema.Validate(ctx)
+	if !ctx.ErrorBag.Empty() || reflect.ValueOf(ctx.Value).Len() != 0 {
+		t.Error("empty string should be an empty array")
+	}
+}
+
+func TestArraySchema_ParseJSON(t *testing.T) {
+	schema := Array().ParseJSON().Items(Number())
+	ctx := NewContext("[1, 2]")
+	schema.Validate(ctx)
+	if !ctx.ErrorBag.Empty() || !reflect.DeepEqual(ctx.Value, []interface{}{1.0, 2.0}) {
+		t.Error("parse json test failed", ctx.ErrorBag)
+	}
+
+	ctx = NewContext("1,2")
+	schema.Validate(ctx)
+	if ctx.ErrorBag.Empty() {
+		t.Error("invalid json should fail")
+	}
+
+	ctx = NewContext("a|b")
+	Array().ParseJSON().ParseString("|").Validate(ctx)
+	if !reflect.DeepEqual(ctx.Value, []interface{}{"a", "b"}) {
+		t.Error("should fall back to separator", ctx.Value)
+	}
+}
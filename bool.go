@@ -1,5 +1,9 @@
 package jio
 
+import (
+	"strings"
+)
+
 // Bool Generates a schema object that matches bool data type
 func Bool() *BoolSchema {
 	return &BoolSchema{
@@ -15,6 +19,7 @@ type BoolSchema struct {
 
 	required *bool
 	rules    []func(*Context)
+	convert  bool
 }
 
 // SetPriority same as AnySchema.SetPriority
@@ -67,6 +72,10 @@ func (b *BoolSchema) Default(value bool) *BoolSchema {
 	b.required = boolPtr(false)
 	return b.PrependTransform(func(ctx *Context) {
 		if ctx.Value == nil {
+			if ctx.options.partial {
+				ctx.Skip()
+				return
+			}
 			ctx.Value = value
 		}
 	})
@@ -88,6 +97,12 @@ func (b *BoolSchema) Equal(value bool) *BoolSchema {
 	})
 }
 
+// Strip same as AnySchema.Strip
+func (b *BoolSchema) Strip() *BoolSchema {
+	b.strip = true
+	return b
+}
+
 // When same as AnySchema.When
 func (b *BoolSchema) When(refPath string, condition interface{}, then Schema) *BoolSchema {
 	return b.Transform(func(ctx *Context) { b.whenEqual(ctx, refPath, condition, then) })
@@ -95,6 +110,7 @@ func (b *BoolSchema) When(refPath string, condition interface{}, then Schema) *B
 
 // Truthy allow for additional values to be considered valid booleans by converting them to true during validation.
 func (b *BoolSchema) Truthy(values ...interface{}) *BoolSchema {
+	b.convert = true
 	return b.Transform(func(ctx *Context) {
 		for _, v := range values {
 			if v == ctx.Value {
@@ -106,6 +122,7 @@ func (b *BoolSchema) Truthy(values ...interface{}) *BoolSchema {
 
 // Falsy allow for additional values to be considered valid booleans by converting them to false during validation.
 func (b *BoolSchema) Falsy(values ...interface{}) *BoolSchema {
+	b.convert = true
 	return b.Transform(func(ctx *Context) {
 		for _, v := range values {
 			if v == ctx.Value {
@@ -115,9 +132,25 @@ func (b *BoolSchema) Falsy(values ...interface{}) *BoolSchema {
 	})
 }
 
+// parseBool parse the strings accepted by Coerce.
+func parseBool(str string) (value bool, ok bool) {
+	switch strings.ToLower(strings.TrimSpace(str)) {
+	case "true", "1", "yes", "on":
+		return true, true
+	case "false", "0", "no", "off":
+		return false, true
+	}
+	return false, false
+}
+
 // Validate same as AnySchema.Validate
 func (b *BoolSchema) Validate(ctx *Context) {
-    if ctx.Value != nil {
+    if ctxValue, ok := ctx.Value.(string); ok && ctx.options.coerce {
+        if value, ok := parseBool(ctxValue); ok {
+            ctx.Value = value
+        }
+    }
+    if ctx.Value != nil && !b.convert {
         if _, ok := (ctx.Value).(bool); !ok {
             ctx.Abort(ErrorTypeBool(ctx))
             return
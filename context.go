@@ -6,26 +6,34 @@ import (
 )
 
 // NewContext Generates a context object with the provided data.
-func NewContext(data interface{}) *Context {
-    return &Context{
+// Non-fatal problems are collected in Warnings, see CollectWarnings.
+func NewContext(data interface{}, opts ...Option) *Context {
+    ctx := &Context{
         root:     data,
         Value:    data,
         ErrorBag: NewErrorBag(),
+        Warnings: NewErrorBag(),
         fields:   make([]string, 0, 3),
+        options:  newOptions(opts),
     }
+    if ctx.options.warnings != nil {
+        ctx.Warnings = ctx.options.warnings
+    }
+    return ctx
 }
 
 // Context contains data and toolkit
 type Context struct {
     Value      interface{}
     ErrorBag   *ErrorBag
+    Warnings   *ErrorBag
     root       interface{}
     parentRoot interface{}
     parent     interface{}
     fields     []string
     storage    map[string]interface{}
     skip       bool
-    kindCache  map[*interface{}]reflect.Kind
+    options    options
 }
 
 // Ref return the reference value.
@@ -61,6 +69,18 @@ func ref(root interface{}, fields []string) (value interface{}, ok bool) {
     return
 }
 
+// fork return a new context for the child value at `field`, sharing the references and options.
+func (ctx *Context) fork(field string, value interface{}) *Context {
+    ctxNew := NewContext(value)
+    ctxNew.root = ctx.root
+    ctxNew.parentRoot = ctx.parentRoot
+    ctxNew.parent = ctx.parent
+    ctxNew.options = ctx.options
+    ctxNew.Warnings = ctx.Warnings
+    ctxNew.fields = append(append(ctxNew.fields, ctx.fields...), field)
+    return ctxNew
+}
+
 // FieldPath the Field path of the current value.
 func (ctx *Context) FieldPath() string {
     return strings.Join(ctx.fields, ".")
@@ -94,15 +114,10 @@ func (ctx *Context) Get(name string) (interface{}, bool) {
     return value, ok
 }
 
-// AssertKind assert the value type and cache.
+// AssertKind assert the value type.
 func (ctx *Context) AssertKind(kind reflect.Kind) bool {
-    if ctx.kindCache == nil {
-        ctx.kindCache = make(map[*interface{}]reflect.Kind)
-    }
-    cachedKind, ok := ctx.kindCache[&ctx.Value]
-    if !ok {
-        cachedKind = reflect.TypeOf(ctx.Value).Kind()
-        ctx.kindCache[&ctx.Value] = cachedKind
+    if ctx.Value == nil {
+        return false
     }
-    return cachedKind == kind
+    return reflect.TypeOf(ctx.Value).Kind() == kind
 }
@@ -0,0 +1,119 @@
+package jio
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// decodeJSON decode the json data like json.Unmarshal into an interface{},
+// enforcing the limits and RejectDuplicateKeys of the options while reading the tokens.
+// Numbers are decoded as json.Number with UseNumber.
+// The errors are reported at the path of the value, prefixed with fields.
+func decodeJSON(data []byte, o options, fields ...string) (value interface{}, err error) {
+	if o.maxDepth <= 0 && o.maxKeys <= 0 && o.maxArrayLength <= 0 && o.maxStringLength <= 0 && !o.rejectDuplicateKeys && !o.useNumber {
+		err = json.Unmarshal(data, &value)
+		return
+	}
+	d := &decoder{dec: json.NewDecoder(bytes.NewReader(data)), o: o, duplicates: NewErrorBag()}
+	if o.useNumber {
+		d.dec.UseNumber()
+	}
+	if value, err = d.value(fields, 1); err != nil {
+		return nil, err
+	}
+	if _, err = d.dec.Token(); err != io.EOF {
+		return nil, errors.New("invalid character after top-level value")
+	}
+	if !d.duplicates.Empty() {
+		return nil, d.duplicates
+	}
+	return value, nil
+}
+
+type decoder struct {
+	dec        *json.Decoder
+	o          options
+	duplicates *ErrorBag
+}
+
+func (d *decoder) value(fields []string, depth int) (interface{}, error) {
+	token, err := d.dec.Token()
+	if err == io.EOF {
+		return nil, io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return nil, err
+	}
+	switch token := token.(type) {
+	case json.Delim:
+		if d.o.maxDepth > 0 && depth > d.o.maxDepth {
+			return nil, d.limitError(LimitDepth, fields, d.o.maxDepth)
+		}
+		if token == '{' {
+			return d.object(fields, depth)
+		}
+		return d.array(fields, depth)
+	case string:
+		if d.o.maxStringLength > 0 && len(token) > d.o.maxStringLength {
+			return nil, d.limitError(LimitStringLength, fields, d.o.maxStringLength)
+		}
+	}
+	return token, nil
+}
+
+func (d *decoder) object(fields []string, depth int) (interface{}, error) {
+	object := make(map[string]interface{})
+	for d.dec.More() {
+		token, err := d.dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key := token.(string)
+		if d.o.maxStringLength > 0 && len(key) > d.o.maxStringLength {
+			return nil, d.limitError(LimitStringLength, fields, d.o.maxStringLength)
+		}
+		_, duplicate := object[key]
+		if !duplicate && d.o.maxKeys > 0 && len(object) >= d.o.maxKeys {
+			return nil, d.limitError(LimitKeys, fields, d.o.maxKeys)
+		}
+		keyFields := append(fields[:len(fields):len(fields)], key)
+		if duplicate && d.o.rejectDuplicateKeys {
+			d.duplicates.Add(FieldError{strings.Join(keyFields, "."), errors.New(ErrorMessageObjectDuplicateKey())})
+		}
+		value, err := d.value(keyFields, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		object[key] = value
+	}
+	if _, err := d.dec.Token(); err != nil {
+		return nil, err
+	}
+	return object, nil
+}
+
+func (d *decoder) array(fields []string, depth int) (interface{}, error) {
+	array := make([]interface{}, 0)
+	for d.dec.More() {
+		if d.o.maxArrayLength > 0 && len(array) >= d.o.maxArrayLength {
+			return nil, d.limitError(LimitArrayLength, fields, d.o.maxArrayLength)
+		}
+		value, err := d.value(append(fields[:len(fields):len(fields)], strconv.Itoa(len(array))), depth+1)
+		if err != nil {
+			return nil, err
+		}
+		array = append(array, value)
+	}
+	if _, err := d.dec.Token(); err != nil {
+		return nil, err
+	}
+	return array, nil
+}
+
+func (d *decoder) limitError(code string, fields []string, limit int) error {
+	return &LimitError{Code: code, Field: strings.Join(fields, "."), Limit: int64(limit)}
+}
@@ -0,0 +1,110 @@
+package jio
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRejectDuplicateKeys(t *testing.T) {
+	schema := Object().Keys(K{
+		"role": String().Required(),
+	})
+	data := []byte(`{"role": "user", "role": "admin"}`)
+	if _, err := ValidateJSON(&data, schema); err != nil {
+		t.Error("duplicate keys should be allowed by default")
+	}
+
+	data = []byte(`{"role": "user", "role": "admin", "profile": {"name": "a", "tags": [{"x": 1, "x": 2}], "name": "b"}}`)
+	_, err := ValidateJSON(&data, schema, RejectDuplicateKeys())
+	if err == nil || err.Error() != "[profile.name is a duplicate key; profile.tags.0.x is a duplicate key; role is a duplicate key]" {
+		t.Error("duplicate keys should be rejected", err)
+	}
+
+	data = []byte(`{"role": "user", "profile": {"role": "admin"}}`)
+	if _, err = ValidateJSON(&data, schema, RejectDuplicateKeys()); err != nil {
+		t.Error("same key in different objects is not a duplicate", err)
+	}
+
+	handler := ValidateRequest(Object().Keys(K{
+		"body": Object().Keys(K{"role": String()}),
+	}), nil, DefaultErrorHandler, RejectDuplicateKeys())(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"role": "user", "role": "admin"}`))
+	r.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "body.role is a duplicate key") {
+		t.Error("ValidateRequest should report duplicate keys in the body", w.Body.String())
+	}
+}
+
+func TestUseNumber(t *testing.T) {
+	schema := Object().Keys(K{
+		"id":    Number().Integer().Min(9007199254740993).Required(),
+		"price": Number().Max(10),
+		"count": Number().Round(),
+	})
+	data := []byte(`{"id":9007199254740993,"price":1.10,"count":2.5}`)
+	dataMap, err := ValidateJSON(&data, schema, UseNumber())
+	if err != nil {
+		t.Error(err)
+	}
+	if dataMap["id"] != json.Number("9007199254740993") {
+		t.Error("id should be a json.Number")
+	}
+	if string(data) != `{"count":3,"id":9007199254740993,"price":1.10}` {
+		t.Error("numbers should keep their text unless changed", string(data))
+	}
+
+	data = []byte(`{"id":9007199254740993}`)
+	if _, err = ValidateJSON(&data, Object().Keys(K{"id": Number().Max(9007199254740992)}), UseNumber()); err == nil {
+		t.Error("json.Number should be compared exactly")
+	}
+	data = []byte(`{"id":9223372036854775808}`)
+	if _, err = ValidateJSON(&data, schema, UseNumber()); err == nil || err.Error() != "[id must be between -9223372036854775808 and 9223372036854775807]" {
+		t.Error("Integer should check the int64 range", err)
+	}
+	data = []byte(`{"id":9007199254740993.5}`)
+	if _, err = ValidateJSON(&data, schema, UseNumber()); err == nil || err.Error() != "[id must be an integer]" {
+		t.Error("Integer should check the fraction exactly", err)
+	}
+
+	data = []byte(`{"id":9007199254740993}`)
+	if _, err = ValidateJSON(&data, schema); err != nil || string(data) != `{"id":9007199254740992}` {
+		t.Error("float64 should stay the default", string(data))
+	}
+
+	data = []byte(`{"ids":[3,1e1,2]}`)
+	_, err = ValidateJSON(&data, Object().Keys(K{"ids": Array().Sort()}), UseNumber())
+	if err != nil || string(data) != `{"ids":[2,3,1e1]}` {
+		t.Error("json.Number should sort as numbers", string(data), err)
+	}
+	if id, ok := (Values{"id": json.Number("9007199254740993")}).GetInt("id"); !ok || id != 9007199254740993 {
+		t.Error("GetInt should read json.Number exactly")
+	}
+}
+
+func TestUseNumberUnique(t *testing.T) {
+	data := []byte(`[1, 1.0, 1e0, 2]`)
+	if _, err := ValidateJSONValue(&data, Array().Unique(), UseNumber()); err == nil {
+		t.Error("json.Number should be unique by value")
+	}
+	data = []byte(`[1, 1.0, 9007199254740993, 9007199254740992, 2]`)
+	if _, err := ValidateJSONValue(&data, Array().Dedupe(), UseNumber()); err != nil || string(data) != `[1,9007199254740993,9007199254740992,2]` {
+		t.Error("json.Number should be deduped by value", string(data), err)
+	}
+	data = []byte(`[0.1, 0.10, 1e-1]`)
+	if _, err := ValidateJSONValue(&data, Array().Unique(), UseNumber()); err == nil {
+		t.Error("fractional json.Number should be unique by value")
+	}
+	data = []byte(`[0.1, 0.10, 0.2, {"a": 0.1}, {"a": 0.10}, "0.1"]`)
+	if _, err := ValidateJSONValue(&data, Array().Dedupe(), UseNumber()); err != nil || string(data) != `[0.1,0.2,{"a":0.1},"0.1"]` {
+		t.Error("fractional json.Number should be deduped by value", string(data), err)
+	}
+	data = []byte(`[9007199254740993, 9007199254740992, 9007199254740994]`)
+	if _, err := ValidateJSONValue(&data, Array().Sort(), UseNumber()); err != nil || string(data) != `[9007199254740992,9007199254740993,9007199254740994]` {
+		t.Error("json.Number should be sorted exactly", string(data), err)
+	}
+}
@@ -4,6 +4,7 @@ import (
     "encoding/json"
     "errors"
     "fmt"
+    "math"
     "sort"
     "strings"
 )
@@ -132,6 +133,41 @@ func ErrorMessageArrayLengthEqual(val int) string {
     return fmt.Sprintf(`must have exactly %s`, items(val))
 }
 
+func ErrorArrayContainsMin(ctx *Context, min, matched int) FieldError {
+    return NewError(ctx, ErrorMessageArrayContainsMin(min, matched))
+}
+
+func ErrorMessageArrayContainsMin(min, matched int) string {
+    return fmt.Sprintf(`must contain at least %s matching the schema [matched: %d]`, items(min), matched)
+}
+
+func ErrorArrayContainsMax(ctx *Context, max, matched int) FieldError {
+    return NewError(ctx, ErrorMessageArrayContainsMax(max, matched))
+}
+
+func ErrorMessageArrayContainsMax(max, matched int) string {
+    return fmt.Sprintf(`cannot contain more than %s matching the schema [matched: %d]`, items(max), matched)
+}
+
+func ErrorArrayUnique(ctx *Context, first int, paths []string) FieldError {
+    return NewError(ctx, ErrorMessageArrayUnique(first, paths))
+}
+
+func ErrorMessageArrayUnique(first int, paths []string) string {
+    if len(paths) == 0 {
+        return fmt.Sprintf("must be unique [duplicate of %d]", first)
+    }
+    return fmt.Sprintf("must be unique [duplicate of %d, uniqueness fields: %s]", first, strings.Join(paths, ", "))
+}
+
+func ErrorArrayItemDropped(ctx *Context, reasons []string) FieldError {
+    return NewError(ctx, ErrorMessageArrayItemDropped(reasons))
+}
+
+func ErrorMessageArrayItemDropped(reasons []string) string {
+    return fmt.Sprintf("was removed because it is invalid [%s]", strings.Join(reasons, "; "))
+}
+
 func ErrorMessageArrayUniqueObjects(fields []string) string {
     return fmt.Sprintf("must be unique [uniqueness fields: %s]", strings.Join(fields, ", "))
 }
@@ -220,6 +256,14 @@ func ErrorMessageTypeInt() string {
     return ErrorMessageType("an integer")
 }
 
+func ErrorNumberInt64Range(ctx *Context) FieldError {
+    return NewError(ctx, ErrorMessageNumberInt64Range())
+}
+
+func ErrorMessageNumberInt64Range() string {
+    return fmt.Sprintf(`must be between %d and %d`, math.MinInt64, math.MaxInt64)
+}
+
 func ErrorTypeNumber(ctx *Context) FieldError {
     return NewError(ctx, ErrorMessageTypeNumber())
 }
@@ -228,6 +272,46 @@ func ErrorMessageTypeNumber() string {
     return ErrorMessageType("a number")
 }
 
+func ErrorTypeFile(ctx *Context) FieldError {
+    return NewError(ctx, ErrorMessageTypeFile())
+}
+
+func ErrorMessageTypeFile() string {
+    return ErrorMessageType("a file")
+}
+
+func ErrorFileCountMin(ctx *Context, min int) FieldError {
+    return NewError(ctx, ErrorMessageFileCountMin(min))
+}
+
+func ErrorMessageFileCountMin(min int) string {
+    return fmt.Sprintf(`must have at least %s`, files(min))
+}
+
+func ErrorFileCountMax(ctx *Context, max int) FieldError {
+    return NewError(ctx, ErrorMessageFileCountMax(max))
+}
+
+func ErrorMessageFileCountMax(max int) string {
+    return fmt.Sprintf(`cannot have more than %s`, files(max))
+}
+
+func ErrorMessageFileSizeMin(min int64) string {
+    return fmt.Sprintf(`must be at least %d bytes`, min)
+}
+
+func ErrorMessageFileSizeMax(max int64) string {
+    return fmt.Sprintf(`cannot be larger than %d bytes`, max)
+}
+
+func ErrorMessageFileType(types []string) string {
+    return fmt.Sprintf(`must be of type [%s]`, strings.Join(types, ", "))
+}
+
+func ErrorMessageFileExtension(extensions []string) string {
+    return fmt.Sprintf(`must have extension [%s]`, strings.Join(extensions, ", "))
+}
+
 func ErrorOneOf(ctx *Context, values []interface{}) FieldError {
     return NewError(ctx, ErrorMessageOneOf(values))
 }
@@ -304,6 +388,58 @@ func ErrorMessageObjectContainsUnknownKeys(unknownKeys []string) string {
     return fmt.Sprintf(`contains unknown keys [%s]`, strings.Join(unknownKeys, ", "))
 }
 
+func ErrorObjectContainsConflictingKeys(ctx *Context, conflictingKeys []string) FieldError {
+    return NewError(ctx,  ErrorMessageObjectContainsConflictingKeys(conflictingKeys))
+}
+
+func ErrorMessageObjectContainsConflictingKeys(conflictingKeys []string) string {
+    return fmt.Sprintf(`contains conflicting keys [%s]`, strings.Join(conflictingKeys, ", "))
+}
+
+func ErrorObjectDuplicateKey(ctx *Context) FieldError {
+    return NewError(ctx, ErrorMessageObjectDuplicateKey())
+}
+
+func ErrorMessageObjectDuplicateKey() string {
+    return fmt.Sprintf(`is a duplicate key`)
+}
+
+func ErrorMessagePointer() string {
+    return ErrorMessageType("a JSON pointer")
+}
+
+func ErrorPatchPathNotFound(ctx *Context, path string) FieldError {
+    return NewError(ctx, ErrorMessagePatchPathNotFound(path))
+}
+
+func ErrorMessagePatchPathNotFound(path string) string {
+    return fmt.Sprintf(`references a nonexistent location %s`, path)
+}
+
+func ErrorPatchPathNotAllowed(ctx *Context, path string) FieldError {
+    return NewError(ctx, ErrorMessagePatchPathNotAllowed(path))
+}
+
+func ErrorMessagePatchPathNotAllowed(path string) string {
+    return fmt.Sprintf(`is not allowed to change %s`, path)
+}
+
+func ErrorPatchTestFailed(ctx *Context, path string) FieldError {
+    return NewError(ctx, ErrorMessagePatchTestFailed(path))
+}
+
+func ErrorMessagePatchTestFailed(path string) string {
+    return fmt.Sprintf(`does not equal the value at %s`, path)
+}
+
+func ErrorPatchMoveIntoChild(ctx *Context, from string) FieldError {
+    return NewError(ctx, ErrorMessagePatchMoveIntoChild(from))
+}
+
+func ErrorMessagePatchMoveIntoChild(from string) string {
+    return fmt.Sprintf(`cannot be a child of %s`, from)
+}
+
 func characters(count int) string {
     str := "character"
     if count != 1 {
@@ -318,4 +454,12 @@ func items(count int) string {
         str += "s"
     }
     return fmt.Sprintf(`%d %s`, count, str)
-}
\ No newline at end of file
+}
+
+func files(count int) string {
+    str := "file"
+    if count != 1 {
+        str += "s"
+    }
+    return fmt.Sprintf(`%d %s`, count, str)
+}
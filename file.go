@@ -0,0 +1,234 @@
+package jio
+
+import (
+	"errors"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// File Generates a schema object that matches the files uploaded with a multipart form.
+// The value is always converted to []*multipart.FileHeader, see ValidateForm.
+func File() *FileSchema {
+	return &FileSchema{
+		rules: make([]func(*Context), 0, 3),
+	}
+}
+
+var _ Schema = new(FileSchema)
+
+// FileSchema match uploaded files
+type FileSchema struct {
+	baseSchema
+
+	required *bool
+	rules    []func(*Context)
+}
+
+// SetPriority same as AnySchema.SetPriority
+func (f *FileSchema) SetPriority(priority int) *FileSchema {
+	f.priority = priority
+	return f
+}
+
+// PrependTransform same as AnySchema.PrependTransform
+func (f *FileSchema) PrependTransform(fn func(*Context)) *FileSchema {
+	f.rules = append([]func(*Context){fn}, f.rules...)
+	return f
+}
+
+// Transform same as AnySchema.Transform
+func (f *FileSchema) Transform(fn func(*Context)) *FileSchema {
+	f.rules = append(f.rules, fn)
+	return f
+}
+
+// Custom adds a custom validation
+func (f *FileSchema) Custom(name string, args ...interface{}) *FileSchema {
+	return f.Transform(func(ctx *Context) {
+		f.baseSchema.custom(ctx, name, args...)
+	})
+}
+
+// Required same as AnySchema.Required
+func (f *FileSchema) Required() *FileSchema {
+	f.required = boolPtr(true)
+	return f.PrependTransform(func(ctx *Context) {
+		if ctx.Value == nil {
+			ctx.Abort(ErrorRequired(ctx))
+		}
+	})
+}
+
+// Optional same as AnySchema.Optional
+func (f *FileSchema) Optional() *FileSchema {
+	f.required = boolPtr(false)
+	return f.PrependTransform(func(ctx *Context) {
+		if ctx.Value == nil {
+			ctx.Skip()
+		}
+	})
+}
+
+// Strip same as AnySchema.Strip
+func (f *FileSchema) Strip() *FileSchema {
+	f.strip = true
+	return f
+}
+
+// When same as AnySchema.When
+func (f *FileSchema) When(refPath string, condition interface{}, then Schema) *FileSchema {
+	return f.Transform(func(ctx *Context) { f.whenEqual(ctx, refPath, condition, then) })
+}
+
+// Check use the provided function to validate each file of the key.
+// The error is reported at the index of the file.
+func (f *FileSchema) Check(fn func(*multipart.FileHeader) error) *FileSchema {
+	return f.Transform(func(ctx *Context) {
+		files, ok := ctx.Value.([]*multipart.FileHeader)
+		if !ok {
+			ctx.Abort(ErrorTypeFile(ctx))
+			return
+		}
+		for i, file := range files {
+			if err := fn(file); err != nil {
+				ctx.ErrorBag.Add(NewError(ctx.fork(strconv.Itoa(i), file), err.Error()))
+			}
+		}
+	})
+}
+
+// Min check if at least `min` files are uploaded.
+func (f *FileSchema) Min(min int) *FileSchema {
+	return f.Transform(func(ctx *Context) {
+		if files, ok := ctx.Value.([]*multipart.FileHeader); ok && len(files) < min {
+			ctx.ErrorBag.Add(ErrorFileCountMin(ctx, min))
+		}
+	})
+}
+
+// Max check if at most `max` files are uploaded.
+func (f *FileSchema) Max(max int) *FileSchema {
+	return f.Transform(func(ctx *Context) {
+		if files, ok := ctx.Value.([]*multipart.FileHeader); ok && len(files) > max {
+			ctx.ErrorBag.Add(ErrorFileCountMax(ctx, max))
+		}
+	})
+}
+
+// MinSize check if the size of each file is greater than or equal to the provided bytes.
+func (f *FileSchema) MinSize(min int64) *FileSchema {
+	return f.Check(func(file *multipart.FileHeader) error {
+		if file.Size < min {
+			return errors.New(ErrorMessageFileSizeMin(min))
+		}
+		return nil
+	})
+}
+
+// MaxSize check if the size of each file is less than or equal to the provided bytes.
+func (f *FileSchema) MaxSize(max int64) *FileSchema {
+	return f.Check(func(file *multipart.FileHeader) error {
+		if file.Size > max {
+			return errors.New(ErrorMessageFileSizeMax(max))
+		}
+		return nil
+	})
+}
+
+// Type check if the Content-Type declared by the client is one of the provided media types.
+// A type like `image/*` matches all subtypes.
+func (f *FileSchema) Type(types ...string) *FileSchema {
+	return f.Check(func(file *multipart.FileHeader) error {
+		if !matchMediaType(file.Header.Get("Content-Type"), types) {
+			return errors.New(ErrorMessageFileType(types))
+		}
+		return nil
+	})
+}
+
+// SniffType check if the content type detected from the file content by http.DetectContentType
+// is one of the provided media types. A type like `image/*` matches all subtypes.
+func (f *FileSchema) SniffType(types ...string) *FileSchema {
+	return f.Check(func(file *multipart.FileHeader) error {
+		content, err := file.Open()
+		if err != nil {
+			return err
+		}
+		defer content.Close()
+		head := make([]byte, 512)
+		n, _ := content.Read(head)
+		if !matchMediaType(http.DetectContentType(head[:n]), types) {
+			return errors.New(ErrorMessageFileType(types))
+		}
+		return nil
+	})
+}
+
+func matchMediaType(contentType string, types []string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	for _, t := range types {
+		t = strings.ToLower(t)
+		if t == mediaType || strings.HasSuffix(t, "/*") && strings.HasPrefix(mediaType, t[:len(t)-1]) {
+			return true
+		}
+	}
+	return false
+}
+
+// Name check if the filename of each file matches the regex.
+func (f *FileSchema) Name(regex string) *FileSchema {
+	re := regexp.MustCompile(regex)
+	return f.Check(func(file *multipart.FileHeader) error {
+		if !re.MatchString(file.Filename) {
+			return errors.New(ErrorMessageMatchPattern(regex))
+		}
+		return nil
+	})
+}
+
+// Extension check if the filename of each file has one of the provided extensions, ignoring case.
+// Extensions can be provided with or without the leading dot.
+func (f *FileSchema) Extension(extensions ...string) *FileSchema {
+	return f.Check(func(file *multipart.FileHeader) error {
+		ext := strings.TrimPrefix(strings.ToLower(filepath.Ext(file.Filename)), ".")
+		for _, allowed := range extensions {
+			if ext != "" && ext == strings.TrimPrefix(strings.ToLower(allowed), ".") {
+				return nil
+			}
+		}
+		return errors.New(ErrorMessageFileExtension(extensions))
+	})
+}
+
+// Validate same as AnySchema.Validate
+func (f *FileSchema) Validate(ctx *Context) {
+	switch ctxValue := ctx.Value.(type) {
+	case *multipart.FileHeader:
+		ctx.Value = []*multipart.FileHeader{ctxValue}
+	case []*multipart.FileHeader:
+		if len(ctxValue) == 0 {
+			ctx.Value = nil
+		}
+	case nil:
+	default:
+		ctx.Abort(ErrorTypeFile(ctx))
+		return
+	}
+	if f.required == nil {
+		f.Optional()
+	}
+	for _, rule := range f.rules {
+		rule(ctx)
+		if ctx.skip {
+			return
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package jio
+
+import (
+	"bytes"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"testing"
+)
+
+var pngHeader = []byte("\x89PNG\x0D\x0A\x1A\x0A\x00\x00\x00\x0DIHDR")
+
+func multipartRequest(t *testing.T, files map[string][]string, contentType string, content []byte) *http.Request {
+	body := &bytes.Buffer{}
+	mw := multipart.NewWriter(body)
+	mw.WriteField("title", "avatar")
+	for field, names := range files {
+		for _, name := range names {
+			header := make(textproto.MIMEHeader)
+			header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`, field, name))
+			header.Set("Content-Type", contentType)
+			part, err := mw.CreatePart(header)
+			if err != nil {
+				t.Fatal(err)
+			}
+			part.Write(content)
+		}
+	}
+	mw.Close()
+	r := httptest.NewRequest(http.MethodPost, "/", body)
+	r.Header.Set("Content-Type", mw.FormDataContentType())
+	return r
+}
+
+func uploadedFiles(t *testing.T, names []string, contentType string, content []byte) []*multipart.FileHeader {
+	r := multipartRequest(t, map[string][]string{"file": names}, contentType, content)
+	if err := r.ParseMultipartForm(1 << 20); err != nil {
+		t.Fatal(err)
+	}
+	return r.MultipartForm.File["file"]
+}
+
+func TestFileSchema_Required(t *testing.T) {
+	ctx := NewContext([]*multipart.FileHeader{})
+	File().Required().Validate(ctx)
+	if ctx.ErrorBag.Empty() {
+		t.Error("should error when no file")
+	}
+
+	ctx = NewContext(nil)
+	File().Validate(ctx)
+	if !ctx.ErrorBag.Empty() {
+		t.Error("default optional should no error")
+	}
+
+	ctx = NewContext("file")
+	File().Validate(ctx)
+	if ctx.ErrorBag.Empty() {
+		t.Error("string is not a file")
+	}
+}
+
+func TestFileSchema_Count(t *testing.T) {
+	files := uploadedFiles(t, []string{"a.png", "b.png", "c.png"}, "image/png", pngHeader)
+	ctx := NewContext(files)
+	File().Min(1).Max(2).Validate(ctx)
+	if ctx.ErrorBag.Error() != "[ cannot have more than 2 files]" {
+		t.Error("count test failed", ctx.ErrorBag)
+	}
+
+	ctx = NewContext(files[0])
+	File().Max(1).Validate(ctx)
+	if !ctx.ErrorBag.Empty() || len(ctx.Value.([]*multipart.FileHeader)) != 1 {
+		t.Error("single file should be wrapped", ctx.ErrorBag)
+	}
+}
+
+func TestFileSchema_Size(t *testing.T) {
+	files := uploadedFiles(t, []string{"a.png"}, "image/png", pngHeader)
+	ctx := NewContext(files)
+	File().MinSize(1).MaxSize(8).Validate(ctx)
+	if ctx.ErrorBag.Error() != "[0 cannot be larger than 8 bytes]" {
+		t.Error("size test failed", ctx.ErrorBag)
+	}
+}
+
+func TestFileSchema_Type(t *testing.T) {
+	files := uploadedFiles(t, []string{"a.png"}, "image/png", []byte("<html></html>"))
+	ctx := NewContext(files)
+	File().Type("image/*").Validate(ctx)
+	if !ctx.ErrorBag.Empty() {
+		t.Error("declared type test failed", ctx.ErrorBag)
+	}
+
+	ctx = NewContext(files)
+	File().SniffType("image/png", "image/jpeg").Validate(ctx)
+	if ctx.ErrorBag.Empty() {
+		t.Error("sniffed type should not match")
+	}
+
+	files = uploadedFiles(t, []string{"a.png"}, "application/octet-stream", pngHeader)
+	ctx = NewContext(files)
+	File().SniffType("image/*").Validate(ctx)
+	if !ctx.ErrorBag.Empty() {
+		t.Error("sniffed type test failed", ctx.ErrorBag)
+	}
+}
+
+func TestFileSchema_NameAndExtension(t *testing.T) {
+	files := uploadedFiles(t, []string{"photo.PNG", "notes.txt"}, "image/png", pngHeader)
+	ctx := NewContext(files)
+	File().Extension(".png", "jpg").Validate(ctx)
+	if ctx.ErrorBag.Error() != "[1 must have extension [.png, jpg]]" {
+		t.Error("extension test failed", ctx.ErrorBag)
+	}
+
+	ctx = NewContext(files)
+	File().Name(`^photo\.`).Validate(ctx)
+	if len(ctx.ErrorBag.StringArray()) != 1 {
+		t.Error("name test failed", ctx.ErrorBag)
+	}
+}
+
+func TestValidateForm_File(t *testing.T) {
+	schema := Object().Keys(K{
+		"title":  String().Required(),
+		"avatar": File().Max(1).MaxSize(1 << 10).SniffType("image/png").Extension("png").Required(),
+	}).Strict()
+	handler := ValidateForm(schema, DefaultErrorHandler)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		form := r.Context().Value(ContextKeyForm).(map[string]interface{})
+		fmt.Fprint(w, form["avatar"].([]*multipart.FileHeader)[0].Filename)
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, multipartRequest(t, map[string][]string{"avatar": {"me.png"}}, "image/png", pngHeader))
+	if w.Body.String() != "me.png" {
+		t.Error("not ok", w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, multipartRequest(t, map[string][]string{"avatar": {"me.gif"}}, "image/png", pngHeader))
+	if w.Code != http.StatusBadRequest {
+		t.Error("should bad request")
+	}
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, multipartRequest(t, nil, "image/png", pngHeader))
+	if w.Code != http.StatusBadRequest {
+		t.Error("missing file should bad request")
+	}
+}
@@ -6,6 +6,9 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
+	"net/textproto"
+	"net/url"
+	"reflect"
 	"strings"
 )
 
@@ -16,8 +19,21 @@ const (
 	ContextKeyQuery contextKey = iota
 	// ContextKeyBody save body map to context with this key
 	ContextKeyBody
+	// ContextKeyForm save form map to context with this key
+	ContextKeyForm
+	// ContextKeyHeader save header map to context with this key
+	ContextKeyHeader
+	// ContextKeyCookie save cookie map to context with this key
+	ContextKeyCookie
+	// ContextKeyParams save path parameter map to context with this key
+	ContextKeyParams
+	// ContextKeyWarnings save the warnings *ErrorBag of the request to context with this key
+	ContextKeyWarnings
 )
 
+// MultipartMaxMemory is the maxMemory passed to http.Request.ParseMultipartForm by ValidateForm.
+var MultipartMaxMemory int64 = 32 << 20
+
 type customValidatorFn func(*Context, ...interface{})
 
 var customValidators = map[string]customValidatorFn{}
@@ -28,27 +44,88 @@ func Register(name string, fn customValidatorFn) {
 }
 
 // ValidateJSON validate the provided json bytes using the schema.
-func ValidateJSON(dataRaw *[]byte, schema Schema) (dataMap map[string]interface{}, err error) {
-	if err = json.Unmarshal(*dataRaw, &dataMap); err != nil {
+// The limits set by MaxDepth, MaxKeys, MaxArrayLength and MaxStringLength are enforced while decoding.
+// The bytes are replaced by the validated value, see PreserveFormat to keep the original formatting.
+// The json must be an object, use ValidateJSONValue for the other values.
+func ValidateJSON(dataRaw *[]byte, schema Schema, opts ...Option) (dataMap map[string]interface{}, err error) {
+	o := newOptions(opts)
+	value, err := decodeJSON(*dataRaw, o)
+	if err != nil {
+		return
+	}
+	if value != nil {
+		var ok bool
+		if dataMap, ok = value.(map[string]interface{}); !ok {
+			err = &json.UnmarshalTypeError{Value: jsonKind(value), Type: reflect.TypeOf(dataMap)}
+			return
+		}
+	}
+	value, err = validateJSON(dataRaw, dataMap, schema, o, opts)
+	if err != nil {
 		return
 	}
-	ctx := NewContext(dataMap)
+	return value.(map[string]interface{}), nil
+}
+
+// ValidateJSONValue is like ValidateJSON, but the json can be any value: an object, array, string, number,
+// boolean or null. The errors of array items are reported at their index, like `0.name`.
+func ValidateJSONValue(dataRaw *[]byte, schema Schema, opts ...Option) (value interface{}, err error) {
+	o := newOptions(opts)
+	if value, err = decodeJSON(*dataRaw, o); err != nil {
+		return
+	}
+	return validateJSON(dataRaw, value, schema, o, opts)
+}
+
+// validateJSON validate the decoded value and replace the bytes with the validated value.
+// The decoded value is returned with the errors if the validation fails.
+func validateJSON(dataRaw *[]byte, value interface{}, schema Schema, o options, opts []Option) (interface{}, error) {
+	ctx := NewContext(value, opts...)
 	schema.Validate(ctx)
 	if !ctx.ErrorBag.Empty() {
-		return dataMap, ctx.ErrorBag
+		return value, ctx.ErrorBag
 	}
-	dataMap = ctx.Value.(map[string]interface{})
-	dataNew, err := json.Marshal(ctx.Value)
+	dataNew, err := encodeJSON(*dataRaw, ctx.Value, o)
 	if err != nil {
-		return
+		return ctx.Value, err
 	}
 	*dataRaw = dataNew
-	return
+	return ctx.Value, nil
+}
+
+// requestWarnings return the request with a warnings bag in its context, shared by the middlewares of the request,
+// and the options collecting the warnings of the validation to this bag.
+func requestWarnings(r *http.Request, opts []Option) (*http.Request, []Option) {
+	bag, ok := r.Context().Value(ContextKeyWarnings).(*ErrorBag)
+	if !ok {
+		bag = NewErrorBag()
+		r = r.WithContext(context.WithValue(r.Context(), ContextKeyWarnings, bag))
+	}
+	return r, append(opts[:len(opts):len(opts)], CollectWarnings(bag))
+}
+
+// jsonKind return the name of the json type of the decoded value.
+func jsonKind(value interface{}) string {
+	switch value.(type) {
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case bool:
+		return "bool"
+	case map[string]interface{}:
+		return "object"
+	}
+	return "number"
 }
 
 // DefaultErrorHandler handle and respond the error
+// The status is 413 Request Entity Too Large when the body exceeds MaxBodyBytes, 400 Bad Request otherwise.
 func DefaultErrorHandler(w http.ResponseWriter, r *http.Request, err error) {
 	code := http.StatusBadRequest
+	if limitErr, ok := err.(*LimitError); ok && limitErr.Code == LimitBodyBytes {
+		code = http.StatusRequestEntityTooLarge
+	}
 	body, _ := json.Marshal(map[string]string{
 		"message": err.Error(),
 	})
@@ -59,46 +136,144 @@ func DefaultErrorHandler(w http.ResponseWriter, r *http.Request, err error) {
 
 // ValidateBody validate the request's body using the schema.
 // If the verification fails, the errorHandler will be used to handle the error.
-func ValidateBody(schema Schema, errorHandler func(http.ResponseWriter, *http.Request, error)) func(next http.Handler) http.Handler {
+// See MaxBodyBytes and MaxDepth for the limits which protect the decoding of large bodies.
+// The body can be any json value like ValidateJSONValue, so bulk endpoints can validate arrays.
+// The validated value is saved to the request context with ContextKeyBody.
+func ValidateBody(schema Schema, errorHandler func(http.ResponseWriter, *http.Request, error), opts ...Option) func(next http.Handler) http.Handler {
+	o := newOptions(opts)
 	return func(next http.Handler) http.Handler {
 		fn := func(w http.ResponseWriter, r *http.Request) {
+			r, opts := requestWarnings(r, opts)
 			var body []byte
 			var err error
 			if strings.Contains(r.Header.Get("Content-type"), "application/json") {
-				body, err = ioutil.ReadAll(r.Body)
+				body, err = readBody(w, r, o)
 				if err != nil {
+					errorHandler(w, r, err)
 					return
 				}
-				r.Body.Close()
 			}
-			dataMap, err := ValidateJSON(&body, schema)
+			value, err := ValidateJSONValue(&body, schema, opts...)
 			if err != nil {
 				errorHandler(w, r, err)
 				return
 			}
 			r.Body = ioutil.NopCloser(bytes.NewBuffer(body))
-			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), ContextKeyBody, dataMap)))
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), ContextKeyBody, value)))
 		}
 		return http.HandlerFunc(fn)
 	}
 }
 
 // ValidateQuery validate the request's query using the schema.
-func ValidateQuery(schema Schema, errorHandler func(http.ResponseWriter, *http.Request, error)) func(next http.Handler) http.Handler {
+// Repeated keys become arrays when the schema of the key is an ArraySchema, see also NestedQuery.
+// String values are converted as described by Coerce unless Coerce(false) is provided.
+func ValidateQuery(schema Schema, errorHandler func(http.ResponseWriter, *http.Request, error), opts ...Option) func(next http.Handler) http.Handler {
+	return validateValues(schema, errorHandler, opts, ContextKeyQuery, func(r *http.Request) url.Values {
+		return r.URL.Query()
+	})
+}
+
+// ValidateHeader validate the request's headers using the schema.
+// Header names are canonicalized by textproto.CanonicalMIMEHeaderKey, so the keys of the schema
+// must be canonical too, like `X-Request-Id` or `Idempotency-Key`.
+// Values are converted like ValidateQuery.
+func ValidateHeader(schema Schema, errorHandler func(http.ResponseWriter, *http.Request, error), opts ...Option) func(next http.Handler) http.Handler {
+	return validateValues(schema, errorHandler, opts, ContextKeyHeader, headerValues)
+}
+
+func headerValues(r *http.Request) url.Values {
+	header := make(url.Values, len(r.Header))
+	for key, values := range r.Header {
+		key = textproto.CanonicalMIMEHeaderKey(key)
+		header[key] = append(header[key], values...)
+	}
+	return header
+}
+
+// ValidateCookie validate the request's cookies using the schema.
+// Values are converted like ValidateQuery.
+func ValidateCookie(schema Schema, errorHandler func(http.ResponseWriter, *http.Request, error), opts ...Option) func(next http.Handler) http.Handler {
+	return validateValues(schema, errorHandler, opts, ContextKeyCookie, cookieValues)
+}
+
+func cookieValues(r *http.Request) url.Values {
+	cookies := make(url.Values)
+	for _, cookie := range r.Cookies() {
+		cookies.Add(cookie.Name, cookie.Value)
+	}
+	return cookies
+}
+
+// ValidatePath validate the request's path parameters returned by the extractor using the schema.
+// Values are converted like ValidateQuery.
+func ValidatePath(schema Schema, extractor PathExtractor, errorHandler func(http.ResponseWriter, *http.Request, error), opts ...Option) func(next http.Handler) http.Handler {
+	return validateValues(schema, errorHandler, opts, ContextKeyParams, extractor.values)
+}
+
+// validateValues validate the string values of the request and save the result to the context with key.
+func validateValues(schema Schema, errorHandler func(http.ResponseWriter, *http.Request, error), opts []Option, key contextKey, values func(*http.Request) url.Values) func(next http.Handler) http.Handler {
+	opts = append([]Option{Coerce(true)}, opts...)
 	return func(next http.Handler) http.Handler {
 		fn := func(w http.ResponseWriter, r *http.Request) {
-			query := make(map[string]interface{})
-			for key, value := range r.URL.Query() {
-				query[key] = value[0]
+			r, opts := requestWarnings(r, opts)
+			data := parseValues(values(r), schema, newOptions(opts))
+			ctx := NewContext(data, opts...)
+			schema.Validate(ctx)
+			if !ctx.ErrorBag.Empty() {
+				errorHandler(w, r, ctx.ErrorBag)
+				return
 			}
-			ctx := NewContext(query)
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), key, ctx.Value)))
+		}
+		return http.HandlerFunc(fn)
+	}
+}
+
+// ValidateForm validate the request's application/x-www-form-urlencoded or multipart/form-data body using the schema.
+// The form values are converted like ValidateQuery, repeated keys become arrays when the schema of the key is an ArraySchema.
+// Uploaded files are stored as []*multipart.FileHeader under their field name, use File to validate them.
+func ValidateForm(schema Schema, errorHandler func(http.ResponseWriter, *http.Request, error), opts ...Option) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			r, opts := requestWarnings(r, append([]Option{Coerce(true)}, opts...))
+			limitBody(w, r, newOptions(opts))
+			values, err := parseForm(r)
+			if err != nil {
+				errorHandler(w, r, bodyLimitError(err, newOptions(opts)))
+				return
+			}
+			if r.MultipartForm != nil {
+				// net/http only removes the temporary files of the original request, r may be a copy.
+				defer r.MultipartForm.RemoveAll()
+			}
+			form := parseValues(values, schema, newOptions(opts))
+			if r.MultipartForm != nil {
+				for key, files := range r.MultipartForm.File {
+					form[key] = files
+				}
+			}
+			ctx := NewContext(form, opts...)
 			schema.Validate(ctx)
 			if !ctx.ErrorBag.Empty() {
 				errorHandler(w, r, ctx.ErrorBag)
 				return
 			}
-			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), ContextKeyQuery, ctx.Value)))
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), ContextKeyForm, ctx.Value)))
 		}
 		return http.HandlerFunc(fn)
 	}
 }
+
+func parseForm(r *http.Request) (url.Values, error) {
+	if strings.Contains(r.Header.Get("Content-Type"), "multipart/form-data") {
+		if err := r.ParseMultipartForm(MultipartMaxMemory); err != nil {
+			return nil, err
+		}
+		return r.MultipartForm.Value, nil
+	}
+	if err := r.ParseForm(); err != nil {
+		return nil, err
+	}
+	return r.PostForm, nil
+}
@@ -1,11 +1,14 @@
 package jio
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"strings"
 	"testing"
 )
@@ -34,6 +37,20 @@ func TestValidateJSON(t *testing.T) {
 	}
 }
 
+func TestValidateJSON_Strip(t *testing.T) {
+	data := []byte(`{"name": "faceair", "confirm": "yes", "admin": true}`)
+	_, err := ValidateJSON(&data, Object().Keys(K{
+		"name":    String(),
+		"confirm": String().Strip(),
+	}).StripUnknown())
+	if err != nil {
+		t.Error("should no error")
+	}
+	if string(data) != `{"name":"faceair"}` {
+		t.Error("should strip keys from json")
+	}
+}
+
 type errReader int
 
 func (errReader) Read(p []byte) (n int, err error) {
@@ -94,7 +111,11 @@ func TestValidateBody(t *testing.T) {
 
 	testRequest := httptest.NewRequest(http.MethodPost, "/something", errReader(0))
 	testRequest.Header.Add("Content-Type", "application/json")
-	handler.ServeHTTP(nil, testRequest)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, testRequest)
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "test error") {
+		t.Error("read error should be handled by the errorHandler", w.Code, w.Body.String())
+	}
 }
 
 func TestValidateQuery(t *testing.T) {
@@ -134,3 +155,278 @@ func TestValidateQuery(t *testing.T) {
 		t.Error("should bad request")
 	}
 }
+
+func TestValidateQuery_Coerce(t *testing.T) {
+	schema := Object().Keys(K{
+		"limit":  Number().Integer().Max(100),
+		"active": Bool(),
+	})
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "ok")
+	})
+
+	w := httptest.NewRecorder()
+	ValidateQuery(schema, DefaultErrorHandler)(ok).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/?limit=10&active=true", nil))
+	if w.Body.String() != "ok" {
+		t.Error("not ok", w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	ValidateQuery(schema, DefaultErrorHandler, Coerce(false))(ok).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/?limit=10", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Error("should bad request")
+	}
+}
+
+func TestValidateQuery_Nested(t *testing.T) {
+	schema := Object().Keys(K{
+		"tag": Array().Items(String()).Min(2),
+		"filter": Object().Keys(K{
+			"status": String().Valid("open", "closed"),
+		}).Strict(),
+	})
+	handler := ValidateQuery(schema, DefaultErrorHandler, NestedQuery())(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "ok")
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/?tag=a&tag=b&filter[status]=open", nil))
+	if w.Body.String() != "ok" {
+		t.Error("not ok", w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/?tag=a&tag=b&filter[owner]=me", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Error("should bad request")
+	}
+}
+
+func TestValidateForm(t *testing.T) {
+	schema := Object().Keys(K{
+		"name": String().Min(3).Required(),
+		"age":  Number().Integer(),
+		"tags": Array().Items(String()),
+	})
+	handler := ValidateForm(schema, DefaultErrorHandler)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		form := r.Context().Value(ContextKeyForm).(map[string]interface{})
+		fmt.Fprint(w, form["name"], form["age"], form["tags"])
+	}))
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("name=faceair&age=18&tags=a&tags=b"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	handler.ServeHTTP(w, r)
+	if w.Body.String() != "faceair18 [a b]" {
+		t.Error("urlencoded form test failed", w.Body.String())
+	}
+
+	body := &bytes.Buffer{}
+	mw := multipart.NewWriter(body)
+	mw.WriteField("name", "faceair")
+	mw.WriteField("tags", "a")
+	mw.Close()
+	w = httptest.NewRecorder()
+	r = httptest.NewRequest(http.MethodPost, "/", body)
+	r.Header.Set("Content-Type", mw.FormDataContentType())
+	handler.ServeHTTP(w, r)
+	if w.Body.String() != "faceair<nil> [a]" {
+		t.Error("multipart form test failed", w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	r = httptest.NewRequest(http.MethodPost, "/", strings.NewReader("name=fa&age=1.5"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	handler.ServeHTTP(w, r)
+	if w.Code != http.StatusBadRequest {
+		t.Error("should bad request")
+	}
+
+	w = httptest.NewRecorder()
+	r = httptest.NewRequest(http.MethodPost, "/", strings.NewReader("broken"))
+	r.Header.Set("Content-Type", "multipart/form-data; boundary=x")
+	handler.ServeHTTP(w, r)
+	if w.Code != http.StatusBadRequest {
+		t.Error("invalid multipart body should bad request")
+	}
+}
+
+func TestValidateForm_TempFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "jio")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	tmpDir := os.Getenv("TMPDIR")
+	os.Setenv("TMPDIR", dir)
+	defer os.Setenv("TMPDIR", tmpDir)
+	maxMemory := MultipartMaxMemory
+	MultipartMaxMemory = 1
+	defer func() { MultipartMaxMemory = maxMemory }()
+
+	var opened bool
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		file, err := r.MultipartForm.File["file"][0].Open()
+		if err == nil {
+			opened = true
+			file.Close()
+		}
+	})
+	middlewares := map[string]func(http.Handler) http.Handler{
+		"ValidateForm": ValidateForm(Object().Keys(K{"file": File().Required()}), DefaultErrorHandler),
+		"ValidateRequest": ValidateRequest(Object().Keys(K{
+			"body": Object().Keys(K{"file": File().Required()}),
+		}), nil, DefaultErrorHandler),
+	}
+	for name, middleware := range middlewares {
+		server := httptest.NewServer(middleware(next))
+		body := &bytes.Buffer{}
+		mw := multipart.NewWriter(body)
+		fw, _ := mw.CreateFormFile("file", "a.bin")
+		fw.Write(bytes.Repeat([]byte("a"), 1<<20))
+		mw.Close()
+		opened = false
+		res, err := http.Post(server.URL, mw.FormDataContentType(), body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+		server.Close()
+		files, _ := ioutil.ReadDir(dir)
+		if res.StatusCode != http.StatusOK || !opened || len(files) != 0 {
+			t.Error("temporary files should be removed after the request", name, res.StatusCode, opened, len(files))
+		}
+	}
+}
+
+func TestValidateHeader(t *testing.T) {
+	schema := Object().Keys(K{
+		"X-Request-Id":    String().Regex(`^[a-f0-9-]+$`).Required(),
+		"Idempotency-Key": String().Min(8),
+		"If-Match":        Array().Items(String()),
+	})
+	handler := ValidateHeader(schema, DefaultErrorHandler)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header := r.Context().Value(ContextKeyHeader).(map[string]interface{})
+		fmt.Fprint(w, header["X-Request-Id"], header["If-Match"])
+	}))
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header["x-request-id"] = []string{"abc-123"}
+	r.Header.Add("If-Match", `"a"`)
+	r.Header.Add("If-Match", `"b"`)
+	handler.ServeHTTP(w, r)
+	if w.Body.String() != `abc-123["a" "b"]` {
+		t.Error("not ok", w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	r = httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Idempotency-Key", "short")
+	handler.ServeHTTP(w, r)
+	if w.Code != http.StatusBadRequest {
+		t.Error("should bad request")
+	}
+}
+
+func TestValidateCookie(t *testing.T) {
+	schema := Object().Keys(K{
+		"session": String().Min(4).Required(),
+		"debug":   Bool(),
+	})
+	handler := ValidateCookie(schema, DefaultErrorHandler)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cookies := r.Context().Value(ContextKeyCookie).(map[string]interface{})
+		fmt.Fprint(w, cookies["session"], cookies["debug"])
+	}))
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.AddCookie(&http.Cookie{Name: "session", Value: "abcdef"})
+	r.AddCookie(&http.Cookie{Name: "debug", Value: "on"})
+	handler.ServeHTTP(w, r)
+	if w.Body.String() != "abcdeftrue" {
+		t.Error("not ok", w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Error("should bad request")
+	}
+}
+
+func TestValidateJSONValue(t *testing.T) {
+	schema := Array().Items(Object().Keys(K{
+		"name": String().Lowercase().Required(),
+	}))
+	data := []byte(`[{"name": "A"}, {"name": "b"}]`)
+	value, err := ValidateJSONValue(&data, schema)
+	if err != nil || len(value.([]interface{})) != 2 || string(data) != `[{"name":"a"},{"name":"b"}]` {
+		t.Error("array root should be validated", string(data), err)
+	}
+	data = []byte(`[{"name": "a"}, {}]`)
+	if _, err = ValidateJSONValue(&data, Array().Ordered(Object().Keys(K{"name": String().Required()}), Object().Keys(K{"name": String().Required()}))); err == nil || err.Error() != "[1.name is required]" {
+		t.Error("array item errors should be reported at their index", err)
+	}
+	for raw, expected := range map[string]interface{}{`"a"`: "a", `1`: float64(1), `true`: true, `null`: nil} {
+		data = []byte(raw)
+		if value, err = ValidateJSONValue(&data, Any()); err != nil || value != expected {
+			t.Error("scalar root should be validated", raw, value, err)
+		}
+	}
+	data = []byte(`[1]`)
+	if _, err = ValidateJSON(&data, Any()); err == nil {
+		t.Error("ValidateJSON should still require an object")
+	}
+
+	handler := ValidateBody(schema, DefaultErrorHandler)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		items := r.Context().Value(ContextKeyBody).([]interface{})
+		fmt.Fprint(w, len(items))
+	}))
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`[{"name": "a"}, {"name": "b"}]`))
+	r.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	if w.Code != http.StatusOK || w.Body.String() != "2" {
+		t.Error("array body should be validated", w.Code, w.Body.String())
+	}
+	r = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`[{"name": "a"}, {"name": 1}]`))
+	r.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "1.name") {
+		t.Error("array body errors should have the item index", w.Code, w.Body.String())
+	}
+}
+
+func TestRequestWarnings(t *testing.T) {
+	shared := NewErrorBag()
+	schema := Object().Keys(K{
+		"ids": Array().Items(Number()).DropInvalid(),
+	})
+	var warnings []string
+	handler := ValidateQuery(schema, DefaultErrorHandler, CollectWarnings(shared))(
+		ValidateBody(schema, DefaultErrorHandler, CollectWarnings(shared))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			warnings = RequestWarnings(r).StringArray()
+		})))
+
+	r := httptest.NewRequest(http.MethodPost, "/?ids=1&ids=a", strings.NewReader(`{"ids": [1, "b", 2]}`))
+	r.Header.Set("Content-Type", "application/json")
+	handler.ServeHTTP(httptest.NewRecorder(), r)
+	if len(warnings) != 2 {
+		t.Error("warnings of all middlewares should be collected for the request", warnings)
+	}
+
+	r = httptest.NewRequest(http.MethodPost, "/?ids=1", strings.NewReader(`{"ids": [1]}`))
+	r.Header.Set("Content-Type", "application/json")
+	handler.ServeHTTP(httptest.NewRecorder(), r)
+	if len(warnings) != 0 {
+		t.Error("warnings should not leak between requests", warnings)
+	}
+	if !shared.Empty() {
+		t.Error("middlewares should not use the CollectWarnings bag")
+	}
+	if !RequestWarnings(httptest.NewRequest(http.MethodGet, "/", nil)).Empty() {
+		t.Error("request without middleware should have no warnings")
+	}
+}
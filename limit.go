@@ -0,0 +1,108 @@
+package jio
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// Codes of the LimitError, one for each limit.
+const (
+	LimitBodyBytes    = "body_too_large"
+	LimitDepth        = "too_deep"
+	LimitKeys         = "too_many_keys"
+	LimitArrayLength  = "array_too_long"
+	LimitStringLength = "string_too_long"
+)
+
+// LimitError is returned when the data exceeds one of the limits set by MaxBodyBytes, MaxDepth,
+// MaxKeys, MaxArrayLength or MaxStringLength. The decoding stops at the first exceeded limit.
+type LimitError struct {
+	Code  string
+	Field string
+	Limit int64
+}
+
+func (err *LimitError) Error() string {
+	var msg string
+	switch err.Code {
+	case LimitBodyBytes:
+		msg = fmt.Sprintf("body must not be larger than %d bytes", err.Limit)
+	case LimitDepth:
+		msg = fmt.Sprintf("must not be nested deeper than %d levels", err.Limit)
+	case LimitKeys:
+		msg = fmt.Sprintf("must not have more than %d keys", err.Limit)
+	case LimitArrayLength:
+		msg = fmt.Sprintf("must not have more than %d items", err.Limit)
+	case LimitStringLength:
+		msg = fmt.Sprintf("must not be longer than %d bytes", err.Limit)
+	}
+	if err.Field == "" {
+		return msg
+	}
+	return fmt.Sprintf("%s %s", err.Field, msg)
+}
+
+// MaxBodyBytes limit the size of the request body read by ValidateBody, ValidateForm and ValidateRequest
+// with http.MaxBytesReader. DefaultErrorHandler responds 413 Request Entity Too Large when it is exceeded.
+func MaxBodyBytes(n int64) Option {
+	return func(o *options) {
+		o.maxBodyBytes = n
+	}
+}
+
+// MaxDepth limit the nesting of objects and arrays in the JSON data, the root value is at depth 1.
+func MaxDepth(n int) Option {
+	return func(o *options) {
+		o.maxDepth = n
+	}
+}
+
+// MaxKeys limit the number of keys of each object in the JSON data.
+func MaxKeys(n int) Option {
+	return func(o *options) {
+		o.maxKeys = n
+	}
+}
+
+// MaxArrayLength limit the number of items of each array in the JSON data.
+func MaxArrayLength(n int) Option {
+	return func(o *options) {
+		o.maxArrayLength = n
+	}
+}
+
+// MaxStringLength limit the length in bytes of each string and object key in the JSON data.
+func MaxStringLength(n int) Option {
+	return func(o *options) {
+		o.maxStringLength = n
+	}
+}
+
+// limitBody wrap the request body with http.MaxBytesReader when MaxBodyBytes is set.
+func limitBody(w http.ResponseWriter, r *http.Request, o options) {
+	if o.maxBodyBytes > 0 && r.Body != nil {
+		r.Body = http.MaxBytesReader(w, r.Body, o.maxBodyBytes)
+	}
+}
+
+// bodyLimitError return a LimitError if err was caused by the MaxBodyBytes limit.
+func bodyLimitError(err error, o options) error {
+	// http.MaxBytesReader returns an unexported error, its message is the only way to recognize it.
+	if o.maxBodyBytes > 0 && err != nil && strings.Contains(err.Error(), "http: request body too large") {
+		return &LimitError{Code: LimitBodyBytes, Limit: o.maxBodyBytes}
+	}
+	return err
+}
+
+// readBody read the whole request body, respecting MaxBodyBytes.
+func readBody(w http.ResponseWriter, r *http.Request, o options) ([]byte, error) {
+	limitBody(w, r, o)
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, bodyLimitError(err, o)
+	}
+	r.Body.Close()
+	return body, nil
+}
@@ -0,0 +1,78 @@
+package jio
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestValidateJSONLimits(t *testing.T) {
+	schema := Any()
+	cases := []struct {
+		data string
+		opt  Option
+		code string
+		err  string
+	}{
+		{`{"a": {"b": {"c": 1}}}`, MaxDepth(2), LimitDepth, "a.b must not be nested deeper than 2 levels"},
+		{`{"a": [[1]]}`, MaxDepth(2), LimitDepth, "a.0 must not be nested deeper than 2 levels"},
+		{`{"a": 1, "b": 2, "c": 3}`, MaxKeys(2), LimitKeys, "must not have more than 2 keys"},
+		{`{"a": [1, 2, 3]}`, MaxArrayLength(2), LimitArrayLength, "a must not have more than 2 items"},
+		{`{"a": ["abc", "abcd"]}`, MaxStringLength(3), LimitStringLength, "a.1 must not be longer than 3 bytes"},
+		{`{"abcd": 1}`, MaxStringLength(3), LimitStringLength, "must not be longer than 3 bytes"},
+	}
+	for _, c := range cases {
+		data := []byte(c.data)
+		_, err := ValidateJSON(&data, schema, c.opt)
+		limitErr, ok := err.(*LimitError)
+		if !ok || limitErr.Code != c.code || err.Error() != c.err {
+			t.Error("limit should be enforced", c.data, err)
+		}
+	}
+
+	data := []byte(`{"a": {"b": [1, 2]}, "c": "abc"}`)
+	dataMap, err := ValidateJSON(&data, schema, MaxDepth(3), MaxKeys(2), MaxArrayLength(2), MaxStringLength(3))
+	if err != nil || dataMap["c"] != "abc" {
+		t.Error("data within the limits should pass", err)
+	}
+	for _, raw := range []string{`{"a": 1} {}`, `{"a": `, `[1]`} {
+		data = []byte(raw)
+		if _, err = ValidateJSON(&data, schema, MaxDepth(3)); err == nil {
+			t.Error("invalid json should fail", raw)
+		}
+	}
+}
+
+func TestValidateBodyMaxBodyBytes(t *testing.T) {
+	handler := ValidateBody(Object(), DefaultErrorHandler, MaxBodyBytes(10))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "ok")
+	}))
+
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"name": "faceair"}`))
+	r.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	if w.Code != http.StatusRequestEntityTooLarge || !strings.Contains(w.Body.String(), "body must not be larger than 10 bytes") {
+		t.Error("large body should be rejected with 413", w.Code, w.Body.String())
+	}
+
+	r = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"a": 1}`))
+	r.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	if w.Code != http.StatusOK || w.Body.String() != "ok" {
+		t.Error("small body should pass", w.Code, w.Body.String())
+	}
+
+	form := ValidateForm(Object(), DefaultErrorHandler, MaxBodyBytes(10))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	r = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(url.Values{"name": {"faceair faceair"}}.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w = httptest.NewRecorder()
+	form.ServeHTTP(w, r)
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Error("large form should be rejected with 413", w.Code, w.Body.String())
+	}
+}
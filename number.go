@@ -1,9 +1,12 @@
 package jio
 
 import (
+    "encoding/json"
     "errors"
     "math"
+    "math/big"
     "strconv"
+    "strings"
 )
 
 // Number Generates a schema object that matches number data type
@@ -19,8 +22,9 @@ var _ Schema = new(NumberSchema)
 type NumberSchema struct {
 	baseSchema
 
-	required *bool
-	rules    []func(*Context)
+	required    *bool
+	rules       []func(*Context)
+	parseString bool
 }
 
 // SetPriority same as AnySchema.SetPriority
@@ -73,6 +77,10 @@ func (n *NumberSchema) Default(value float64) *NumberSchema {
 	n.required = boolPtr(false)
 	return n.PrependTransform(func(ctx *Context) {
 		if ctx.Value == nil {
+			if ctx.options.partial {
+				ctx.Skip()
+				return
+			}
 			ctx.Value = value
 		}
 	})
@@ -87,14 +95,20 @@ func (n *NumberSchema) Set(value float64) *NumberSchema {
 
 // Equal same as AnySchema.Equal
 func (n *NumberSchema) Equal(value float64) *NumberSchema {
-	return n.Check(func(ctxValue float64) error {
-		if value != ctxValue {
+	return n.compare(func(cmp func(float64) int) error {
+		if cmp(value) != 0 {
 			return errors.New(ErrorMessageEqual(value))
 		}
 		return nil
 	})
 }
 
+// Strip same as AnySchema.Strip
+func (n *NumberSchema) Strip() *NumberSchema {
+	n.strip = true
+	return n
+}
+
 // When same as AnySchema.When
 func (n *NumberSchema) When(refPath string, condition interface{}, then Schema) *NumberSchema {
 	return n.Transform(func(ctx *Context) { n.whenEqual(ctx, refPath, condition, then) })
@@ -102,9 +116,10 @@ func (n *NumberSchema) When(refPath string, condition interface{}, then Schema)
 
 // Check use the provided function to validate the value of the key.
 // Throws an error whenEqual the value is not float64.
+// A json.Number value (see UseNumber) is passed as the nearest float64.
 func (n *NumberSchema) Check(f func(float64) error) *NumberSchema {
 	return n.Transform(func(ctx *Context) {
-		ctxValue, ok := ctx.Value.(float64)
+		ctxValue, ok := numberValue(ctx.Value)
 		if !ok {
 			ctx.Abort(ErrorTypeNumber(ctx))
 			return
@@ -115,12 +130,28 @@ func (n *NumberSchema) Check(f func(float64) error) *NumberSchema {
 	})
 }
 
+// compare is like Check, but compares json.Number values exactly with the provided values.
+func (n *NumberSchema) compare(f func(cmp func(float64) int) error) *NumberSchema {
+	return n.Transform(func(ctx *Context) {
+		if _, ok := numberValue(ctx.Value); !ok {
+			ctx.Abort(ErrorTypeNumber(ctx))
+			return
+		}
+		cmp := func(value float64) int {
+			return compareNumber(ctx.Value, value)
+		}
+		if err := f(cmp); err != nil {
+			ctx.ErrorBag.Add(NewError(ctx, err.Error()))
+		}
+	})
+}
+
 // Valid same as AnySchema.Valid
 func (n *NumberSchema) Valid(values ...float64) *NumberSchema {
-	return n.Check(func(ctxValue float64) error {
+	return n.compare(func(cmp func(float64) int) error {
 		var isValid bool
 		for _, v := range values {
-			if v == ctxValue {
+			if cmp(v) == 0 {
 				isValid = true
 				break
 			}
@@ -134,8 +165,8 @@ func (n *NumberSchema) Valid(values ...float64) *NumberSchema {
 
 // Min check if the value is greater than or equal to the provided value.
 func (n *NumberSchema) Min(min float64) *NumberSchema {
-	return n.Check(func(ctxValue float64) error {
-		if ctxValue < min {
+	return n.compare(func(cmp func(float64) int) error {
+		if cmp(min) < 0 {
 			return errors.New(ErrorMessageMin(min))
 		}
 		return nil
@@ -144,8 +175,8 @@ func (n *NumberSchema) Min(min float64) *NumberSchema {
 
 // Max check if the value is less than or equal to the provided value.
 func (n *NumberSchema) Max(max float64) *NumberSchema {
-	return n.Check(func(ctxValue float64) error {
-		if ctxValue > max {
+	return n.compare(func(cmp func(float64) int) error {
+		if cmp(max) > 0 {
 			return errors.New(ErrorMessageMax(max))
 		}
 		return nil
@@ -155,14 +186,14 @@ func (n *NumberSchema) Max(max float64) *NumberSchema {
 // GreaterThanOrEqualToField checks if the value is greater than or equal to the value at `refPath`
 func (n *NumberSchema) GreaterThanOrEqualToField(refPath string) *NumberSchema {
     return n.Transform(func (ctx *Context) {
-        ctxValue, ok := ctx.Value.(float64)
+        ctxValue, ok := numberValue(ctx.Value)
         if !ok {
             ctx.Abort(ErrorTypeNumber(ctx))
             return
         }
 
         r, _ := ctx.Ref(refPath)
-        refValue, ok := r.(float64)
+        refValue, ok := numberValue(r)
         if !ok {
             ctx.ErrorBag.AddFromContext(ctx, ErrorMessageTypeNumber())
             return
@@ -175,25 +206,45 @@ func (n *NumberSchema) GreaterThanOrEqualToField(refPath string) *NumberSchema {
 }
 
 // Integer check if the value is integer.
+// A json.Number value (see UseNumber) is checked exactly and must also fit in an int64.
 func (n *NumberSchema) Integer() *NumberSchema {
-	return n.Check(func(ctxValue float64) error {
-		if ctxValue != math.Trunc(ctxValue) {
-			return errors.New(ErrorMessageTypeInt())
+	return n.Transform(func(ctx *Context) {
+		ctxValue, ok := numberValue(ctx.Value)
+		if !ok {
+			ctx.Abort(ErrorTypeNumber(ctx))
+			return
+		}
+		number, ok := ctx.Value.(json.Number)
+		if !ok {
+			if ctxValue != math.Trunc(ctxValue) {
+				ctx.ErrorBag.Add(ErrorTypeInt(ctx))
+			}
+			return
+		}
+		exact, _ := new(big.Rat).SetString(string(number))
+		if !exact.IsInt() {
+			ctx.ErrorBag.Add(ErrorTypeInt(ctx))
+		} else if !exact.Num().IsInt64() {
+			ctx.ErrorBag.Add(ErrorNumberInt64Range(ctx))
 		}
-		return nil
 	})
 }
 
 // Convert use the provided function to convert the value of the key.
 // Throws an error whenEqual the value is not float64.
+// A json.Number value (see UseNumber) is kept unchanged when the function returns the same float64.
 func (n *NumberSchema) Convert(f func(float64) float64) *NumberSchema {
 	return n.Transform(func(ctx *Context) {
-		ctxValue, ok := ctx.Value.(float64)
+		ctxValue, ok := numberValue(ctx.Value)
 		if !ok {
 			ctx.Abort(ErrorTypeNumber(ctx))
 			return
 		}
-		ctx.Value = f(ctxValue)
+		value := f(ctxValue)
+		if _, ok := ctx.Value.(json.Number); ok && value == ctxValue {
+			return
+		}
+		ctx.Value = value
 	})
 }
 
@@ -212,29 +263,72 @@ func (n *NumberSchema) Round() *NumberSchema {
 	return n.Convert(math.Round)
 }
 
-// ParseString convert the string value to float64.
+// ParseString convert the string value to float64 before the other rules run.
 // Validation will be skipped whenEqual this value is not string.
 // But if this value is not a valid number, an error will be thrown.
 func (n *NumberSchema) ParseString() *NumberSchema {
-	return n.Transform(func(ctx *Context) {
-		if ctxValue, ok := ctx.Value.(string); ok {
-			value, err := strconv.ParseFloat(ctxValue, 64)
-			if err != nil {
-				ctx.Abort(ErrorTypeNumber(ctx))
-				return
-			}
-			ctx.Value = value
+	n.parseString = true
+	return n
+}
+
+// parseNumber parse a string as a finite float64.
+func parseNumber(str string) (float64, error) {
+	value, err := strconv.ParseFloat(strings.TrimSpace(str), 64)
+	if err == nil && (math.IsNaN(value) || math.IsInf(value, 0)) {
+		err = errors.New(ErrorMessageTypeNumber())
+	}
+	return value, err
+}
+
+// numberValue return the float64 value of a float64 or json.Number.
+// A json.Number out of the float64 range is returned as an infinity.
+func numberValue(value interface{}) (float64, bool) {
+	switch value := value.(type) {
+	case float64:
+		return value, true
+	case json.Number:
+		f, err := strconv.ParseFloat(string(value), 64)
+		if err != nil && !errors.Is(err, strconv.ErrRange) {
+			return 0, false
 		}
-	})
+		return f, true
+	}
+	return 0, false
+}
+
+// compareNumber return -1, 0 or 1 comparing a float64 or json.Number with the provided value.
+// json.Number is compared exactly.
+func compareNumber(value interface{}, target float64) int {
+	if number, ok := value.(json.Number); ok {
+		x, ok := new(big.Rat).SetString(string(number))
+		if y := new(big.Rat); ok && !math.IsInf(target, 0) && !math.IsNaN(target) {
+			return x.Cmp(y.SetFloat64(target))
+		}
+	}
+	x, _ := numberValue(value)
+	if x < target {
+		return -1
+	} else if x > target {
+		return 1
+	}
+	return 0
 }
 
 // Validate same as AnySchema.Validate
 func (n *NumberSchema) Validate(ctx *Context) {
+    if ctxValue, ok := ctx.Value.(string); ok && (n.parseString || ctx.options.coerce) {
+        value, err := parseNumber(ctxValue)
+        if err != nil {
+            ctx.Abort(ErrorTypeNumber(ctx))
+            return
+        }
+        ctx.Value = value
+    }
     if ctx.Value != nil {
         if ctxValue, ok := ctx.Value.(int); ok {
             ctx.Value = float64(ctxValue)
         }
-        if _, ok := (ctx.Value).(float64); !ok {
+        if _, ok := numberValue(ctx.Value); !ok {
             ctx.Abort(ErrorTypeNumber(ctx))
             return
         }
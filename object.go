@@ -2,6 +2,7 @@ package jio
 
 import (
 	"sort"
+	"strings"
 )
 
 type objectItem struct {
@@ -26,7 +27,7 @@ func (k K) sort() []objectItem {
 // Object Generates a schema object that matches object data type
 func Object() *ObjectSchema {
 	return &ObjectSchema{
-		rules: make([]func(*Context), 0, 3),
+		rules: make([]objectRule, 0, 3),
 	}
 }
 
@@ -36,9 +37,38 @@ var _ Schema = new(ObjectSchema)
 type ObjectSchema struct {
 	baseSchema
 
-	children *K
-	required *bool
-	rules    []func(*Context)
+	children        *K
+	keyRules        map[string]int
+	required        *bool
+	rules           []objectRule
+	renames         []objectRename
+	caseInsensitive bool
+}
+
+// RenameOptions controls how Rename moves a key.
+type RenameOptions struct {
+	// Alias keeps the original key in the output alongside the new one.
+	Alias bool
+	// Override replaces the value of the new key when it already exists.
+	// Otherwise an error is thrown when both keys are present.
+	Override bool
+}
+
+// objectRule receives the schema being validated instead of capturing it,
+// so a cloned schema never shares keys with the original.
+type objectRule struct {
+	fn   func(*ObjectSchema, *Context)
+	keys bool
+}
+
+func transformRule(f func(*Context)) objectRule {
+	return objectRule{fn: func(_ *ObjectSchema, ctx *Context) { f(ctx) }}
+}
+
+type objectRename struct {
+	from    string
+	to      string
+	options RenameOptions
 }
 
 // SetPriority same as AnySchema.SetPriority
@@ -49,13 +79,13 @@ func (o *ObjectSchema) SetPriority(priority int) *ObjectSchema {
 
 // PrependTransform same as AnySchema.PrependTransform
 func (o *ObjectSchema) PrependTransform(f func(*Context)) *ObjectSchema {
-	o.rules = append([]func(*Context){f}, o.rules...)
+	o.rules = append([]objectRule{transformRule(f)}, o.rules...)
 	return o
 }
 
 // Transform same as AnySchema.Transform
 func (o *ObjectSchema) Transform(f func(*Context)) *ObjectSchema {
-	o.rules = append(o.rules, f)
+	o.rules = append(o.rules, transformRule(f))
 	return o
 }
 
@@ -91,12 +121,17 @@ func (o *ObjectSchema) Default(value map[string]interface{}) *ObjectSchema {
 	o.required = boolPtr(false)
 	return o.PrependTransform(func(ctx *Context) {
 		if ctx.Value == nil {
+			if ctx.options.partial {
+				ctx.Skip()
+				return
+			}
 			ctx.Value = value
 		}
 	})
 }
 
 // With require the presence of these keys.
+// The check is disabled by PatchMode.
 func (o *ObjectSchema) With(keys ...string) *ObjectSchema {
 	return o.Transform(func(ctx *Context) {
 		ctxValue, ok := ctx.Value.(map[string]interface{})
@@ -105,6 +140,10 @@ func (o *ObjectSchema) With(keys ...string) *ObjectSchema {
 			return
 		}
 
+		if ctx.options.partial {
+			return
+		}
+
 		var missingKeys []string
 		for _, key := range keys {
 			_, ok := ctxValue[key]
@@ -141,7 +180,7 @@ func (o *ObjectSchema) Without(keys ...string) *ObjectSchema {
 
 // Strict forbids keys that are not in this schema
 func (o *ObjectSchema) Strict() *ObjectSchema {
-    return o.Transform(func(ctx *Context) {
+    return o.rule(func(o *ObjectSchema, ctx *Context) {
         if o.children == nil {
             return
         }
@@ -153,7 +192,7 @@ func (o *ObjectSchema) Strict() *ObjectSchema {
 
         var unknownKeys []string
         for k, _ := range ctxValue {
-            if _, ok := (*o.children)[k]; !ok {
+            if _, ok := (*o.children)[k]; !ok && !o.isAliasKey(k) {
                 unknownKeys = append(unknownKeys, k)
             }
         }
@@ -165,52 +204,378 @@ func (o *ObjectSchema) Strict() *ObjectSchema {
     })
 }
 
+// Rename move the value of key `from` to key `to` before the keys are validated.
+// Renames are applied in the order of registration, so the child schemas,
+// With, Without and Strict only see the new key.
+func (o *ObjectSchema) Rename(from, to string, options ...RenameOptions) *ObjectSchema {
+	rename := objectRename{from: from, to: to}
+	if len(options) > 0 {
+		rename.options = options[0]
+	}
+	o.renames = append(o.renames, rename)
+	return o
+}
+
+// Alias accept the aliases as alternative names of the key on input.
+// The value is always stored under `key` in the output.
+func (o *ObjectSchema) Alias(key string, aliases ...string) *ObjectSchema {
+	for _, alias := range aliases {
+		o.Rename(alias, key)
+	}
+	return o
+}
+
+// CaseInsensitive match the input keys to the keys of this schema ignoring case.
+// Matched keys are renamed to the spelling used in Keys before validation.
+func (o *ObjectSchema) CaseInsensitive() *ObjectSchema {
+	o.caseInsensitive = true
+	return o
+}
+
+// lookupKey find the input key matching `key`, ignoring case if enabled.
+func (o *ObjectSchema) lookupKey(ctxValue map[string]interface{}, key string) (string, bool) {
+	if _, ok := ctxValue[key]; ok {
+		return key, true
+	}
+	if !o.caseInsensitive {
+		return "", false
+	}
+	matched := o.foldKeys(ctxValue, key)
+	if len(matched) == 0 {
+		return "", false
+	}
+	return matched[0], true
+}
+
+func (o *ObjectSchema) foldKeys(ctxValue map[string]interface{}, key string) []string {
+	var matched []string
+	for k := range ctxValue {
+		if strings.EqualFold(k, key) {
+			matched = append(matched, k)
+		}
+	}
+	sort.Strings(matched)
+	return matched
+}
+
+// normalizeKeys apply renames, aliases and case-insensitive matching to the value.
+func (o *ObjectSchema) normalizeKeys(ctx *Context) {
+	ctxValue, ok := ctx.Value.(map[string]interface{})
+	if !ok {
+		return
+	}
+	for _, rename := range o.renames {
+		from, ok := o.lookupKey(ctxValue, rename.from)
+		if !ok || from == rename.to {
+			continue
+		}
+		if _, exists := ctxValue[rename.to]; exists && !rename.options.Override {
+			ctx.ErrorBag.Add(ErrorObjectContainsConflictingKeys(ctx, []string{from, rename.to}))
+			continue
+		}
+		ctxValue[rename.to] = ctxValue[from]
+		if !rename.options.Alias {
+			delete(ctxValue, from)
+		}
+	}
+	if !o.caseInsensitive || o.children == nil {
+		return
+	}
+	for key := range *o.children {
+		if _, ok := ctxValue[key]; ok {
+			continue
+		}
+		matched := o.foldKeys(ctxValue, key)
+		if len(matched) > 1 {
+			ctx.ErrorBag.Add(ErrorObjectContainsConflictingKeys(ctx, matched))
+			continue
+		}
+		if len(matched) == 1 {
+			ctxValue[key] = ctxValue[matched[0]]
+			delete(ctxValue, matched[0])
+		}
+	}
+}
+
+// isAliasKey report whether the key is kept in the output by a Rename with Alias.
+func (o *ObjectSchema) isAliasKey(key string) bool {
+	for _, rename := range o.renames {
+		if rename.options.Alias && (rename.from == key || o.caseInsensitive && strings.EqualFold(rename.from, key)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Strip same as AnySchema.Strip
+func (o *ObjectSchema) Strip() *ObjectSchema {
+	o.strip = true
+	return o
+}
+
+// StripUnknown remove keys that are not in this schema instead of throwing an error.
+func (o *ObjectSchema) StripUnknown() *ObjectSchema {
+	return o.rule(func(o *ObjectSchema, ctx *Context) {
+		if o.children == nil {
+			return
+		}
+		ctxValue, ok := ctx.Value.(map[string]interface{})
+		if !ok {
+			ctx.Abort(ErrorTypeObject(ctx))
+			return
+		}
+		for k := range ctxValue {
+			if _, ok := (*o.children)[k]; !ok && !o.isAliasKey(k) {
+				delete(ctxValue, k)
+			}
+		}
+	})
+}
+
 // When same as AnySchema.When
 func (o *ObjectSchema) When(refPath string, condition interface{}, then Schema) *ObjectSchema {
 	return o.Transform(func(ctx *Context) { o.whenEqual(ctx, refPath, condition, then) })
 }
 
 // Keys set the object keys's schema
+// The keys are validated at the position of this call among the other rules,
+// a key declared again by a later call is validated at the position of the later call.
 func (o *ObjectSchema) Keys(children K) *ObjectSchema {
-    if o.children != nil {
-        for k, s := range children {
-            (*o.children)[k] = s
-        }
-    } else {
-        o.children = &children
-    }
+	if o.children == nil {
+		o.children = &K{}
+	}
+	if o.keyRules == nil {
+		o.keyRules = make(map[string]int)
+	}
+	index := len(o.rules)
+	for k, s := range children {
+		(*o.children)[k] = s
+		o.keyRules[k] = index
+	}
+	o.rules = append(o.rules, objectRule{fn: func(o *ObjectSchema, ctx *Context) {
+		validateKeys(o, ctx, index)
+	}, keys: true})
+	return o
+}
 
-	return o.Transform(func(ctx *Context) {
-		ctxValue, ok := ctx.Value.(map[string]interface{})
-		if !ok {
-			ctx.Abort(ErrorTypeObject(ctx))
-			return
+// rule append a rule which needs the schema's own keys.
+func (o *ObjectSchema) rule(f func(*ObjectSchema, *Context)) *ObjectSchema {
+	o.rules = append(o.rules, objectRule{fn: f})
+	return o
+}
+
+// validateKeys validate the children declared by the Keys rule at index.
+func validateKeys(o *ObjectSchema, ctx *Context, index int) {
+	ctxValue, ok := ctx.Value.(map[string]interface{})
+	if !ok {
+		ctx.Abort(ErrorTypeObject(ctx))
+		return
+	}
+	fields := make([]string, len(ctx.fields))
+	copy(fields, ctx.fields)
+
+	defer func() {
+		ctx.fields = fields
+		ctx.Value = ctxValue
+		ctx.skip = false
+	}()
+
+	for _, obj := range o.children.sort() {
+		if o.keyRules[obj.key] != index {
+			continue
+		}
+		value, exists := ctxValue[obj.key]
+		if !exists && ctx.options.partial {
+			continue
+		}
+		ctx.parent = ctxValue
+		ctx.skip = false
+		ctx.fields = append(fields, obj.key)
+		ctx.Value = value
+		if _, ok := obj.schema.(*ObjectSchema); ok && ctx.parentRoot == nil {
+			ctx.parentRoot = ctx.parent
 		}
-		fields := make([]string, len(ctx.fields))
-		copy(fields, ctx.fields)
+		obj.schema.Validate(ctx)
+		if s, ok := obj.schema.(strippable); ok && s.stripped() {
+			delete(ctxValue, obj.key)
+			continue
+		}
+		if ctx.ErrorBag.Empty() && !ctx.skip {
+			ctxValue[obj.key] = ctx.Value
+		}
+	}
+}
 
-		defer func() {
-			ctx.fields = fields
-			ctx.Value = ctxValue
-		}()
+// Clone returns a copy of the schema which can be changed without affecting the original.
+// The child ObjectSchemas are cloned recursively, also when they are wrapped by Partial or RequiredAll.
+// The other child schemas, including an ArraySchema and the schemas of its items, are shared with the
+// original since their rules can not be copied, so build a new schema instead of changing them.
+func (o *ObjectSchema) Clone() *ObjectSchema {
+	clone := *o
+	clone.rules = append(make([]objectRule, 0, len(o.rules)), o.rules...)
+	clone.renames = append([]objectRename(nil), o.renames...)
+	if o.required != nil {
+		clone.required = boolPtr(*o.required)
+	}
+	if o.children != nil {
+		children := make(K, len(*o.children))
+		for k, s := range *o.children {
+			children[k] = cloneSchema(s)
+		}
+		clone.children = &children
+	}
+	if o.keyRules != nil {
+		clone.keyRules = make(map[string]int, len(o.keyRules))
+		for k, index := range o.keyRules {
+			clone.keyRules[k] = index
+		}
+	}
+	return &clone
+}
 
-		for _, obj := range children.sort() {
-			value, _ := ctxValue[obj.key]
-			ctx.parent = ctxValue
-			ctx.skip = false
-			ctx.fields = append(fields, obj.key)
-			ctx.Value = value
-			if _, ok := obj.schema.(*ObjectSchema); ok && ctx.parentRoot == nil {
-			    ctx.parentRoot = ctx.parent
-            }
-			obj.schema.Validate(ctx)
-			if ctx.ErrorBag.Empty() && !ctx.skip {
-				ctxValue[obj.key] = ctx.Value
+func cloneSchema(schema Schema) Schema {
+	switch s := schema.(type) {
+	case *ObjectSchema:
+		return s.Clone()
+	case *optionalSchema:
+		return &optionalSchema{cloneSchema(s.Schema)}
+	case *requiredSchema:
+		return &requiredSchema{cloneSchema(s.Schema)}
+	case *coerceSchema:
+		return &coerceSchema{cloneSchema(s.Schema)}
+	}
+	return schema
+}
+
+// Extend returns a copy of the schema with additional keys.
+// Keys which already exist are replaced.
+func (o *ObjectSchema) Extend(children K) *ObjectSchema {
+	return o.Clone().Keys(children)
+}
+
+// Pick returns a copy of the schema which only contains the provided keys.
+func (o *ObjectSchema) Pick(keys ...string) *ObjectSchema {
+	picked := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		picked[key] = true
+	}
+	return o.filterKeys(func(key string) bool { return picked[key] })
+}
+
+// Omit returns a copy of the schema without the provided keys.
+func (o *ObjectSchema) Omit(keys ...string) *ObjectSchema {
+	omitted := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		omitted[key] = true
+	}
+	return o.filterKeys(func(key string) bool { return !omitted[key] })
+}
+
+func (o *ObjectSchema) filterKeys(keep func(string) bool) *ObjectSchema {
+	clone := o.Clone()
+	if clone.children != nil {
+		for key := range *clone.children {
+			if !keep(key) {
+				delete(*clone.children, key)
 			}
 		}
+	}
+	return clone
+}
+
+// Partial returns a copy of the schema in which the provided keys are optional.
+// All keys are made optional when no key is provided.
+// A missing optional key is skipped, so its Required and Default rules do not run.
+func (o *ObjectSchema) Partial(keys ...string) *ObjectSchema {
+	return o.wrapKeys(keys, func(schema Schema) Schema {
+		return &optionalSchema{schema}
 	})
 }
 
+// RequiredAll returns a copy of the schema in which the provided keys are required.
+// All keys are made required when no key is provided.
+func (o *ObjectSchema) RequiredAll(keys ...string) *ObjectSchema {
+	return o.wrapKeys(keys, func(schema Schema) Schema {
+		return &requiredSchema{schema}
+	})
+}
+
+func (o *ObjectSchema) wrapKeys(keys []string, wrap func(Schema) Schema) *ObjectSchema {
+	clone := o.Clone()
+	if clone.children == nil {
+		return clone
+	}
+	if len(keys) == 0 {
+		for key := range *clone.children {
+			keys = append(keys, key)
+		}
+	}
+	for _, key := range keys {
+		if schema, ok := (*clone.children)[key]; ok {
+			(*clone.children)[key] = wrap(schema)
+		}
+	}
+	return clone
+}
+
+// Merge returns a new schema containing the keys and rules of both schemas.
+// The keys of other take precedence over keys with the same name.
+func (o *ObjectSchema) Merge(other *ObjectSchema) *ObjectSchema {
+	merged := o.Clone()
+	for _, rule := range other.rules {
+		if rule.keys {
+			continue
+		}
+		merged.rules = append(merged.rules, rule)
+	}
+	if other.children != nil {
+		children := make(K, len(*other.children))
+		for k, s := range *other.children {
+			children[k] = cloneSchema(s)
+		}
+		merged.Keys(children)
+	}
+	merged.renames = append(merged.renames, other.renames...)
+	merged.caseInsensitive = merged.caseInsensitive || other.caseInsensitive
+	return merged
+}
+
+// optionalSchema skips the wrapped schema when the value is undefined or null.
+type optionalSchema struct {
+	Schema
+}
+
+func (s *optionalSchema) Validate(ctx *Context) {
+	if ctx.Value == nil {
+		ctx.Skip()
+		return
+	}
+	s.Schema.Validate(ctx)
+}
+
+func (s *optionalSchema) stripped() bool {
+	strip, ok := s.Schema.(strippable)
+	return ok && strip.stripped()
+}
+
+// requiredSchema throws an error when the value is undefined or null.
+type requiredSchema struct {
+	Schema
+}
+
+func (s *requiredSchema) Validate(ctx *Context) {
+	if ctx.Value == nil {
+		ctx.Abort(ErrorRequired(ctx))
+		return
+	}
+	s.Schema.Validate(ctx)
+}
+
+func (s *requiredSchema) stripped() bool {
+	strip, ok := s.Schema.(strippable)
+	return ok && strip.stripped()
+}
+
 // Validate same as AnySchema.Validate
 func (o *ObjectSchema) Validate(ctx *Context) {
     if ctx.Value != nil {
@@ -222,8 +587,9 @@ func (o *ObjectSchema) Validate(ctx *Context) {
 	if o.required == nil {
 		o.Optional()
 	}
+	o.normalizeKeys(ctx)
 	for _, rule := range o.rules {
-		rule(ctx)
+		rule.fn(o, ctx)
 		if ctx.skip {
 			return
 		}
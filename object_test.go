@@ -182,6 +182,34 @@ func TestObjectSchema_Keys(t *testing.T) {
 	}
 }
 
+func TestObjectSchema_KeysOrder(t *testing.T) {
+	schema := Object().Keys(K{
+		"a": String(),
+	}).Transform(func(ctx *Context) {
+		ctx.Value.(map[string]interface{})["b"] = "x"
+	}).Keys(K{
+		"b": Number(),
+	})
+	ctx := NewContext(map[string]interface{}{"a": "a"})
+	schema.Validate(ctx)
+	if ctx.ErrorBag.Error() != "[b must be a number]" {
+		t.Error("keys should be validated at the position of their Keys call", ctx.ErrorBag)
+	}
+
+	schema = Object().Keys(K{
+		"a": String(),
+	}).Transform(func(ctx *Context) {
+		ctx.Value.(map[string]interface{})["a"] = 1.0
+	}).Keys(K{
+		"a": Number(),
+	})
+	ctx = NewContext(map[string]interface{}{"a": "a"})
+	schema.Validate(ctx)
+	if !ctx.ErrorBag.Empty() {
+		t.Error("key declared again should be validated at the later Keys call", ctx.ErrorBag)
+	}
+}
+
 func TestObjectSchema_Validate(t *testing.T) {
 	schema := Object()
 	ctx := NewContext(nil)
@@ -196,3 +224,260 @@ func TestObjectSchema_Validate(t *testing.T) {
 		t.Error("not map")
 	}
 }
+
+func TestObjectSchema_Rename(t *testing.T) {
+	schema := Object().Keys(K{
+		"user_name": String().Required(),
+	}).Rename("userName", "user_name").Strict()
+
+	value := map[string]interface{}{"userName": "faceair"}
+	ctx := NewContext(value)
+	schema.Validate(ctx)
+	if !ctx.ErrorBag.Empty() {
+		t.Error("rename test failed")
+	}
+	if _, ok := value["userName"]; ok || value["user_name"] != "faceair" {
+		t.Error("should rename key")
+	}
+
+	ctx = NewContext(map[string]interface{}{"userName": "a", "user_name": "b"})
+	schema.Validate(ctx)
+	if ctx.ErrorBag.Empty() {
+		t.Error("conflicting keys should failed")
+	}
+
+	schema = Object().Keys(K{
+		"user_name": String().Required(),
+	}).Rename("userName", "user_name", RenameOptions{Alias: true, Override: true}).Strict()
+	value = map[string]interface{}{"userName": "a", "user_name": "b"}
+	ctx = NewContext(value)
+	schema.Validate(ctx)
+	if !ctx.ErrorBag.Empty() {
+		t.Error("override test failed")
+	}
+	if value["userName"] != "a" || value["user_name"] != "a" {
+		t.Error("should keep alias and override key")
+	}
+}
+
+func TestObjectSchema_Alias(t *testing.T) {
+	schema := Object().Keys(K{
+		"user_name": String().Required(),
+	}).Alias("user_name", "userName", "username")
+
+	value := map[string]interface{}{"username": "faceair"}
+	ctx := NewContext(value)
+	schema.Validate(ctx)
+	if !ctx.ErrorBag.Empty() || value["user_name"] != "faceair" || len(value) != 1 {
+		t.Error("alias test failed")
+	}
+}
+
+func TestObjectSchema_CaseInsensitive(t *testing.T) {
+	schema := Object().Keys(K{
+		"userName": String().Required(),
+	}).CaseInsensitive().Strict()
+
+	value := map[string]interface{}{"USERNAME": "faceair"}
+	ctx := NewContext(value)
+	schema.Validate(ctx)
+	if !ctx.ErrorBag.Empty() || value["userName"] != "faceair" || len(value) != 1 {
+		t.Error("case insensitive test failed")
+	}
+
+	ctx = NewContext(map[string]interface{}{"USERNAME": "a", "username": "b"})
+	schema.Validate(ctx)
+	if ctx.ErrorBag.Empty() {
+		t.Error("conflicting keys should failed")
+	}
+}
+
+func TestObjectSchema_StripUnknown(t *testing.T) {
+	schema := Object().Keys(K{
+		"name": String(),
+	}).StripUnknown()
+
+	value := map[string]interface{}{"name": "faceair", "admin": true}
+	ctx := NewContext(value)
+	schema.Validate(ctx)
+	if !ctx.ErrorBag.Empty() {
+		t.Error("strip unknown should no error")
+	}
+	if _, ok := value["admin"]; ok || value["name"] != "faceair" {
+		t.Error("should strip unknown keys")
+	}
+}
+
+func TestObjectSchema_Strip(t *testing.T) {
+	schema := Object().Keys(K{
+		"password":         String().Min(6).Required(),
+		"confirm_password": Any().Equal("secret").Strip(),
+	})
+
+	value := map[string]interface{}{"password": "secret", "confirm_password": "secret"}
+	ctx := NewContext(value)
+	schema.Validate(ctx)
+	if !ctx.ErrorBag.Empty() {
+		t.Error("strip should no error")
+	}
+	if _, ok := value["confirm_password"]; ok {
+		t.Error("should strip key")
+	}
+
+	ctx = NewContext(map[string]interface{}{"password": "secret", "confirm_password": "other"})
+	schema.Validate(ctx)
+	if ctx.ErrorBag.Empty() {
+		t.Error("stripped key should still be validated")
+	}
+}
+
+func TestObjectSchema_Clone(t *testing.T) {
+	schema := Object().Keys(K{
+		"name": String().Required(),
+	}).Strict()
+	clone := schema.Clone().Keys(K{
+		"age": Number().Required(),
+	})
+
+	ctx := NewContext(map[string]interface{}{"name": "faceair"})
+	schema.Validate(ctx)
+	if !ctx.ErrorBag.Empty() {
+		t.Error("original schema should not be changed")
+	}
+
+	ctx = NewContext(map[string]interface{}{"name": "faceair", "age": 18.0})
+	schema.Validate(ctx)
+	if ctx.ErrorBag.Empty() {
+		t.Error("original schema should not know the new key")
+	}
+
+	ctx = NewContext(map[string]interface{}{"name": "faceair", "age": 18.0})
+	clone.Validate(ctx)
+	if !ctx.ErrorBag.Empty() {
+		t.Error("clone should know the new key")
+	}
+}
+
+func TestObjectSchema_CloneNested(t *testing.T) {
+	profile := Object().Keys(K{"name": String()})
+	base := Object().Keys(K{"profile": profile}).Partial()
+	clone := base.Clone()
+	(*base.children)["profile"].(*optionalSchema).Schema.(*ObjectSchema).Keys(K{"age": Number().Required()})
+	ctx := NewContext(map[string]interface{}{"profile": map[string]interface{}{"name": "faceair"}})
+	clone.Validate(ctx)
+	if !ctx.ErrorBag.Empty() {
+		t.Error("wrapped object keys should be cloned", ctx.ErrorBag)
+	}
+
+	inner := Object().Keys(K{"x": String()})
+	base = Object().Keys(K{"items": Array().Items(inner)})
+	extended := base.Extend(K{"y": String()})
+	inner.Keys(K{"z": Number().Required()})
+	ctx = NewContext(map[string]interface{}{"items": []interface{}{map[string]interface{}{"x": "a"}}})
+	extended.Validate(ctx)
+	if ctx.ErrorBag.Empty() {
+		t.Error("array item schemas are documented to be shared")
+	}
+}
+
+func TestObjectSchema_Extend(t *testing.T) {
+	children := K{"name": String().Required()}
+	schema := Object().Keys(children)
+	extended := schema.Extend(K{"id": Number().Required()})
+	if len(children) != 1 || len(*schema.children) != 1 || len(*extended.children) != 2 {
+		t.Error("extend should not mutate the original keys")
+	}
+
+	ctx := NewContext(map[string]interface{}{"name": "faceair"})
+	extended.Validate(ctx)
+	if ctx.ErrorBag.Empty() {
+		t.Error("extended key should be validated")
+	}
+}
+
+func TestObjectSchema_PickAndOmit(t *testing.T) {
+	schema := Object().Keys(K{
+		"id":   Number().Required(),
+		"name": String().Required(),
+	}).Strict()
+
+	ctx := NewContext(map[string]interface{}{"name": "faceair"})
+	schema.Omit("id").Validate(ctx)
+	if !ctx.ErrorBag.Empty() {
+		t.Error("omit test failed")
+	}
+
+	ctx = NewContext(map[string]interface{}{"id": 1.0})
+	schema.Pick("id").Validate(ctx)
+	if !ctx.ErrorBag.Empty() {
+		t.Error("pick test failed")
+	}
+
+	ctx = NewContext(map[string]interface{}{"id": 1.0, "name": "faceair"})
+	schema.Pick("id").Validate(ctx)
+	if ctx.ErrorBag.Empty() {
+		t.Error("picked schema should be strict")
+	}
+}
+
+func TestObjectSchema_PartialAndRequiredAll(t *testing.T) {
+	schema := Object().Keys(K{
+		"name": String().Min(3).Required(),
+		"age":  Number().Default(18),
+	})
+
+	value := map[string]interface{}{}
+	ctx := NewContext(value)
+	schema.Partial().Validate(ctx)
+	if !ctx.ErrorBag.Empty() {
+		t.Error("partial keys should be optional")
+	}
+	if _, ok := value["age"]; ok {
+		t.Error("partial keys should not set default")
+	}
+
+	ctx = NewContext(map[string]interface{}{"name": "a"})
+	schema.Partial().Validate(ctx)
+	if ctx.ErrorBag.Empty() {
+		t.Error("partial keys should keep rules")
+	}
+
+	ctx = NewContext(map[string]interface{}{"name": "faceair"})
+	schema.RequiredAll().Validate(ctx)
+	if ctx.ErrorBag.Empty() {
+		t.Error("required all should require age")
+	}
+
+	ctx = NewContext(map[string]interface{}{})
+	schema.Validate(ctx)
+	if ctx.ErrorBag.Empty() {
+		t.Error("original schema should not be changed")
+	}
+}
+
+func TestObjectSchema_Merge(t *testing.T) {
+	a := Object().Keys(K{"name": String().Required()})
+	b := Object().Keys(K{"age": Number().Required()}).Strict()
+	merged := a.Merge(b)
+
+	ctx := NewContext(map[string]interface{}{"name": "faceair", "age": 18.0})
+	merged.Validate(ctx)
+	if !ctx.ErrorBag.Empty() {
+		t.Error("merge test failed")
+	}
+
+	ctx = NewContext(map[string]interface{}{"name": "faceair", "age": 18.0, "extra": true})
+	merged.Validate(ctx)
+	if ctx.ErrorBag.Empty() {
+		t.Error("merged schema should be strict")
+	}
+
+	ctx = NewContext(map[string]interface{}{"name": "faceair"})
+	merged.Validate(ctx)
+	if ctx.ErrorBag.Empty() {
+		t.Error("merged schema should require age")
+	}
+	if len(*a.children) != 1 || len(*b.children) != 1 {
+		t.Error("merge should not mutate the original schemas")
+	}
+}
@@ -0,0 +1,89 @@
+package jio
+
+// Option configures the validation of a value.
+type Option func(*options)
+
+type options struct {
+	partial     bool
+	patchPaths  []string
+	warnings    *ErrorBag
+	nestedQuery bool
+	coerce      bool
+
+	maxBodyBytes    int64
+	maxDepth        int
+	maxKeys         int
+	maxArrayLength  int
+	maxStringLength int
+
+	rejectDuplicateKeys bool
+	useNumber           bool
+	preserveFormat      bool
+}
+
+func newOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// PatchMode validate only the keys present in the data, as expected by PATCH endpoints.
+// Absent keys are skipped at every level, so Required and RequiredAll only reject the keys present with a null value,
+// With checks are disabled and no Default value is set. All other rules still apply to the keys that are present.
+func PatchMode() Option {
+	return func(o *options) {
+		o.partial = true
+	}
+}
+
+// CollectWarnings add the non-fatal problems found during validation to the bag,
+// for example the items removed by ArraySchema.DropInvalid.
+// It only applies to NewContext and ValidateJSON: the middlewares collect the warnings
+// of each request to its own bag, read it in the handler with RequestWarnings.
+func CollectWarnings(bag *ErrorBag) Option {
+	return func(o *options) {
+		o.warnings = bag
+	}
+}
+
+// RejectDuplicateKeys report the keys which appear more than once in the same JSON object,
+// at any depth, as errors at their path. The schema validation does not run when duplicates are found.
+// By default encoding/json silently keeps the last value of a duplicate key.
+func RejectDuplicateKeys() Option {
+	return func(o *options) {
+		o.rejectDuplicateKeys = true
+	}
+}
+
+// UseNumber decode the JSON numbers as json.Number instead of float64, keeping their exact text.
+// NumberSchema compares json.Number exactly and Integer also checks the int64 range, so large ids
+// like 9007199254740993 are neither corrupted nor accepted out of range. When the data is rewritten,
+// the numbers keep their original text unless a rule changed the value.
+func UseNumber() Option {
+	return func(o *options) {
+		o.useNumber = true
+	}
+}
+
+// PreserveFormat rewrite the validated JSON by patching the original document instead of encoding
+// the whole value again. The keys keep their order and the untouched values keep their original text
+// and whitespace, only the values changed by the rules are encoded again. Removed keys are dropped,
+// keys added by Default or Rename are appended in alphabetical order and arrays whose length changed
+// are encoded again as a whole.
+func PreserveFormat() Option {
+	return func(o *options) {
+		o.preserveFormat = true
+	}
+}
+
+// Coerce convert string values to the type expected by Number, Bool and Array schemas.
+// Numbers are parsed with strconv.ParseFloat, booleans accept true/false, 1/0, yes/no and on/off
+// ignoring case, and a string becomes a single item array (an empty string becomes an empty array).
+// Enabled by default for ValidateQuery, disabled by default for JSON.
+func Coerce(enable bool) Option {
+	return func(o *options) {
+		o.coerce = enable
+	}
+}
@@ -0,0 +1,92 @@
+package jio
+
+import (
+	"testing"
+)
+
+func TestPatchMode(t *testing.T) {
+	schema := Object().Keys(K{
+		"name":  String().Min(3).Required(),
+		"age":   Number().Default(18),
+		"admin": Bool().Required(),
+		"profile": Object().Keys(K{
+			"email": String().Required(),
+			"bio":   String().Max(5).Default("hi"),
+		}).With("email").Required(),
+		"tags": Array().Items(Object().Keys(K{
+			"id":   Number().Required(),
+			"name": String().Min(2).Required(),
+		})),
+	})
+
+	value := map[string]interface{}{
+		"name":    "faceair",
+		"profile": map[string]interface{}{},
+		"tags":    []interface{}{map[string]interface{}{"name": "go"}},
+	}
+	ctx := NewContext(value, PatchMode())
+	schema.Validate(ctx)
+	if !ctx.ErrorBag.Empty() {
+		t.Error("absent keys should be skipped", ctx.ErrorBag)
+	}
+	if _, ok := value["age"]; ok {
+		t.Error("should not set default value")
+	}
+	if _, ok := value["profile"].(map[string]interface{})["bio"]; ok {
+		t.Error("should not set nested default value")
+	}
+
+	ctx = NewContext(map[string]interface{}{
+		"name": "a",
+		"tags": []interface{}{map[string]interface{}{"name": "g"}},
+	}, PatchMode())
+	schema.Validate(ctx)
+	if len(ctx.ErrorBag.StringArray()) != 2 {
+		t.Error("present keys should be validated", ctx.ErrorBag)
+	}
+
+	ctx = NewContext(map[string]interface{}{
+		"name":    nil,
+		"profile": map[string]interface{}{"email": nil},
+	}, PatchMode())
+	schema.Validate(ctx)
+	if ctx.ErrorBag.Error() != "[name is required; profile.email is required]" {
+		t.Error("present null should fail required keys", ctx.ErrorBag)
+	}
+
+	ctx = NewContext(map[string]interface{}{"name": "faceair"})
+	schema.Validate(ctx)
+	if ctx.ErrorBag.Empty() {
+		t.Error("required keys should fail without patch mode")
+	}
+}
+
+func TestCoerce(t *testing.T) {
+	schema := Object().Keys(K{
+		"limit":  Number().Integer(),
+		"active": Bool(),
+		"tags":   Array().Items(String()),
+		"empty":  Array(),
+	})
+	value := map[string]interface{}{"limit": " 10", "active": "Yes", "tags": "go", "empty": ""}
+	ctx := NewContext(value, Coerce(true))
+	schema.Validate(ctx)
+	if !ctx.ErrorBag.Empty() {
+		t.Error("coerce test failed", ctx.ErrorBag)
+	}
+	if value["limit"] != 10.0 || value["active"] != true || len(value["tags"].([]interface{})) != 1 || len(value["empty"].([]interface{})) != 0 {
+		t.Error("values should be converted", value)
+	}
+
+	ctx = NewContext(map[string]interface{}{"limit": "NaN", "active": "maybe"}, Coerce(true))
+	schema.Validate(ctx)
+	if len(ctx.ErrorBag.StringArray()) != 2 {
+		t.Error("invalid strings should fail", ctx.ErrorBag)
+	}
+
+	ctx = NewContext(map[string]interface{}{"limit": "10"})
+	schema.Validate(ctx)
+	if ctx.ErrorBag.Empty() {
+		t.Error("strings should not be converted by default")
+	}
+}
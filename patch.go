@@ -0,0 +1,404 @@
+package jio
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ApplyMergePatch apply the JSON merge patch (RFC 7396) to the document and validate the result using the schema.
+// The document is not modified, keys with a null value in the patch are deleted from the result.
+// A merge patch mirrors the document structure, so the paths of the returned ErrorBag are the paths of the patch's keys.
+func ApplyMergePatch(document map[string]interface{}, patchRaw []byte, schema Schema, opts ...Option) (dataMap map[string]interface{}, err error) {
+	var patch interface{}
+	if err = json.Unmarshal(patchRaw, &patch); err != nil {
+		return
+	}
+	if _, ok := patch.(map[string]interface{}); !ok {
+		ctx := NewContext(patch, opts...)
+		ctx.ErrorBag.Add(ErrorTypeObject(ctx))
+		return nil, ctx.ErrorBag
+	}
+	dataMap = mergePatch(deepCopy(document), patch).(map[string]interface{})
+	ctx := NewContext(dataMap, opts...)
+	schema.Validate(ctx)
+	if !ctx.ErrorBag.Empty() {
+		return dataMap, ctx.ErrorBag
+	}
+	dataMap, ok := ctx.Value.(map[string]interface{})
+	if !ok {
+		ctx.ErrorBag.Add(ErrorTypeObject(ctx))
+		return nil, ctx.ErrorBag
+	}
+	return
+}
+
+func mergePatch(target, patch interface{}) interface{} {
+	patchMap, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	targetMap, ok := target.(map[string]interface{})
+	if !ok {
+		targetMap = make(map[string]interface{}, len(patchMap))
+	}
+	for key, value := range patchMap {
+		if value == nil {
+			delete(targetMap, key)
+			continue
+		}
+		targetMap[key] = mergePatch(targetMap[key], value)
+	}
+	return targetMap
+}
+
+// deepCopy copy the maps and slices decoded from json, other values are shared.
+func deepCopy(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			m[key] = deepCopy(item)
+		}
+		return m
+	case []interface{}:
+		s := make([]interface{}, len(v))
+		for i, item := range v {
+			s[i] = deepCopy(item)
+		}
+		return s
+	default:
+		return value
+	}
+}
+
+var patchOperationsSchema = Array().Items(Object().Keys(K{
+	"op":    String().Valid("add", "remove", "replace", "move", "copy", "test").Required(),
+	"path":  String().Check(checkPointer).Required(),
+	"from":  String().Check(checkPointer).Optional(),
+	"value": Any().Optional(),
+}).Transform(func(ctx *Context) {
+	ctxValue := ctx.Value.(map[string]interface{})
+	switch ctxValue["op"] {
+	case "add", "replace", "test":
+		if _, ok := ctxValue["value"]; !ok {
+			ctx.ErrorBag.Add(ErrorObjectMissingRequiredKeys(ctx, []string{"value"}))
+		}
+	case "move", "copy":
+		if _, ok := ctxValue["from"]; !ok {
+			ctx.ErrorBag.Add(ErrorObjectMissingRequiredKeys(ctx, []string{"from"}))
+		}
+	}
+}).Required()).Required()
+
+// ApplyJSONPatch apply the JSON patch (RFC 6902) to the document and validate the result using the schema.
+// The structure of every operation is checked before any of them is applied, and the document is not modified.
+// Use AllowedPatchPaths to restrict the locations the operations can change.
+func ApplyJSONPatch(document map[string]interface{}, patchRaw []byte, schema Schema, opts ...Option) (dataMap map[string]interface{}, err error) {
+	var patch interface{}
+	if err = json.Unmarshal(patchRaw, &patch); err != nil {
+		return
+	}
+	ctx := NewContext(patch, opts...)
+	patchOperationsSchema.Validate(ctx)
+	if !ctx.ErrorBag.Empty() {
+		return nil, ctx.ErrorBag
+	}
+	operations := patch.([]interface{})
+	if ctx.options.patchPaths != nil {
+		for i, operation := range operations {
+			checkPatchPaths(ctx, i, operation.(map[string]interface{}))
+		}
+		if !ctx.ErrorBag.Empty() {
+			return nil, ctx.ErrorBag
+		}
+	}
+
+	var data interface{} = deepCopy(document)
+	for i, operation := range operations {
+		if data, err = applyPatchOperation(ctx, i, data, operation.(map[string]interface{})); err != nil {
+			return nil, err
+		}
+	}
+	if _, ok := data.(map[string]interface{}); !ok {
+		ctx = NewContext(data, opts...)
+		ctx.ErrorBag.Add(ErrorTypeObject(ctx))
+		return nil, ctx.ErrorBag
+	}
+
+	ctx = NewContext(data, opts...)
+	schema.Validate(ctx)
+	dataMap, _ = ctx.Value.(map[string]interface{})
+	if !ctx.ErrorBag.Empty() {
+		return dataMap, ctx.ErrorBag
+	}
+	return
+}
+
+// AllowedPatchPaths restrict the locations which can be changed by ApplyJSONPatch.
+// A path allows itself and all its children, and a `*` segment matches any single segment,
+// for example `/tags/*` allows changing the items of `tags` but not replacing the whole array.
+func AllowedPatchPaths(paths ...string) Option {
+	return func(o *options) {
+		o.patchPaths = append(append([]string{}, o.patchPaths...), paths...)
+	}
+}
+
+func checkPatchPaths(ctx *Context, i int, operation map[string]interface{}) {
+	paths := []string{"path"}
+	if operation["op"] == "test" {
+		return
+	}
+	if operation["op"] == "move" {
+		paths = append(paths, "from")
+	}
+	for _, key := range paths {
+		path := operation[key].(string)
+		if !patchPathAllowed(ctx.options.patchPaths, path) {
+			ctx.fields = []string{strconv.Itoa(i), key}
+			ctx.ErrorBag.Add(ErrorPatchPathNotAllowed(ctx, path))
+		}
+	}
+}
+
+func patchPathAllowed(allowed []string, path string) bool {
+	tokens := strings.Split(path, "/")
+	for _, pattern := range allowed {
+		patternTokens := strings.Split(pattern, "/")
+		if len(patternTokens) > len(tokens) {
+			continue
+		}
+		matched := true
+		for j, token := range patternTokens {
+			if token != "*" && token != tokens[j] {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+func checkPointer(pointer string) error {
+	if pointer != "" && !strings.HasPrefix(pointer, "/") {
+		return errors.New(ErrorMessagePointer())
+	}
+	for i := 0; i < len(pointer); i++ {
+		if pointer[i] == '~' && (i+1 == len(pointer) || (pointer[i+1] != '0' && pointer[i+1] != '1')) {
+			return errors.New(ErrorMessagePointer())
+		}
+	}
+	return nil
+}
+
+func pointerTokens(pointer string) []string {
+	if pointer == "" {
+		return nil
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.Replace(strings.Replace(token, "~1", "/", -1), "~0", "~", -1)
+	}
+	return tokens
+}
+
+func applyPatchOperation(ctx *Context, i int, data interface{}, operation map[string]interface{}) (interface{}, error) {
+	path := operation["path"].(string)
+	tokens := pointerTokens(path)
+	ctx.fields = []string{strconv.Itoa(i), "path"}
+	notFound := func() error {
+		ctx.ErrorBag.Add(ErrorPatchPathNotFound(ctx, path))
+		return ctx.ErrorBag
+	}
+
+	switch operation["op"] {
+	case "add":
+		data, ok := pointerAdd(data, tokens, deepCopy(operation["value"]))
+		if !ok {
+			return nil, notFound()
+		}
+		return data, nil
+	case "remove":
+		data, _, ok := pointerRemove(data, tokens)
+		if !ok {
+			return nil, notFound()
+		}
+		return data, nil
+	case "replace":
+		if _, ok := pointerGet(data, tokens); !ok {
+			return nil, notFound()
+		}
+		data, _ = pointerReplace(data, tokens, deepCopy(operation["value"]))
+		return data, nil
+	case "test":
+		value, ok := pointerGet(data, tokens)
+		if !ok {
+			return nil, notFound()
+		}
+		if !reflect.DeepEqual(value, operation["value"]) {
+			ctx.fields = []string{strconv.Itoa(i), "value"}
+			ctx.ErrorBag.Add(ErrorPatchTestFailed(ctx, path))
+			return nil, ctx.ErrorBag
+		}
+		return data, nil
+	}
+
+	from := operation["from"].(string)
+	fromTokens := pointerTokens(from)
+	value, ok := pointerGet(data, fromTokens)
+	if !ok {
+		ctx.fields = []string{strconv.Itoa(i), "from"}
+		ctx.ErrorBag.Add(ErrorPatchPathNotFound(ctx, from))
+		return nil, ctx.ErrorBag
+	}
+	if operation["op"] == "move" {
+		if strings.HasPrefix(path, from+"/") {
+			ctx.ErrorBag.Add(ErrorPatchMoveIntoChild(ctx, from))
+			return nil, ctx.ErrorBag
+		}
+		data, _, _ = pointerRemove(data, fromTokens)
+	} else {
+		value = deepCopy(value)
+	}
+	data, ok = pointerAdd(data, tokens, value)
+	if !ok {
+		return nil, notFound()
+	}
+	return data, nil
+}
+
+func pointerGet(data interface{}, tokens []string) (interface{}, bool) {
+	for _, token := range tokens {
+		switch container := data.(type) {
+		case map[string]interface{}:
+			value, ok := container[token]
+			if !ok {
+				return nil, false
+			}
+			data = value
+		case []interface{}:
+			index, ok := pointerIndex(token, len(container)-1)
+			if !ok {
+				return nil, false
+			}
+			data = container[index]
+		default:
+			return nil, false
+		}
+	}
+	return data, true
+}
+
+func pointerIndex(token string, max int) (int, bool) {
+	if token == "" || (len(token) > 1 && token[0] == '0') {
+		return 0, false
+	}
+	index, err := strconv.Atoi(token)
+	if err != nil || index < 0 || index > max {
+		return 0, false
+	}
+	return index, true
+}
+
+// pointerUpdate call fn with the parent container of the location and store the returned container back.
+func pointerUpdate(data interface{}, tokens []string, fn func(interface{}, string) (interface{}, bool)) (interface{}, bool) {
+	if len(tokens) == 1 {
+		return fn(data, tokens[0])
+	}
+	child, ok := pointerGet(data, tokens[:1])
+	if !ok {
+		return nil, false
+	}
+	child, ok = pointerUpdate(child, tokens[1:], fn)
+	if !ok {
+		return nil, false
+	}
+	switch container := data.(type) {
+	case map[string]interface{}:
+		container[tokens[0]] = child
+	case []interface{}:
+		index, _ := pointerIndex(tokens[0], len(container)-1)
+		container[index] = child
+	}
+	return data, true
+}
+
+func pointerAdd(data interface{}, tokens []string, value interface{}) (interface{}, bool) {
+	if len(tokens) == 0 {
+		return value, true
+	}
+	return pointerUpdate(data, tokens, func(parent interface{}, token string) (interface{}, bool) {
+		switch container := parent.(type) {
+		case map[string]interface{}:
+			container[token] = value
+			return container, true
+		case []interface{}:
+			if token == "-" {
+				return append(container, value), true
+			}
+			index, ok := pointerIndex(token, len(container))
+			if !ok {
+				return nil, false
+			}
+			container = append(container, nil)
+			copy(container[index+1:], container[index:])
+			container[index] = value
+			return container, true
+		}
+		return nil, false
+	})
+}
+
+func pointerReplace(data interface{}, tokens []string, value interface{}) (interface{}, bool) {
+	if len(tokens) == 0 {
+		return value, true
+	}
+	return pointerUpdate(data, tokens, func(parent interface{}, token string) (interface{}, bool) {
+		switch container := parent.(type) {
+		case map[string]interface{}:
+			container[token] = value
+			return container, true
+		case []interface{}:
+			index, ok := pointerIndex(token, len(container)-1)
+			if !ok {
+				return nil, false
+			}
+			container[index] = value
+			return container, true
+		}
+		return nil, false
+	})
+}
+
+func pointerRemove(data interface{}, tokens []string) (interface{}, interface{}, bool) {
+	if len(tokens) == 0 {
+		return nil, data, true
+	}
+	var removed interface{}
+	data, ok := pointerUpdate(data, tokens, func(parent interface{}, token string) (interface{}, bool) {
+		switch container := parent.(type) {
+		case map[string]interface{}:
+			value, ok := container[token]
+			if !ok {
+				return nil, false
+			}
+			removed = value
+			delete(container, token)
+			return container, true
+		case []interface{}:
+			index, ok := pointerIndex(token, len(container)-1)
+			if !ok {
+				return nil, false
+			}
+			removed = container[index]
+			return append(container[:index:index], container[index+1:]...), true
+		}
+		return nil, false
+	})
+	return data, removed, ok
+}
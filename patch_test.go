@@ -0,0 +1,148 @@
+package jio
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestApplyMergePatch(t *testing.T) {
+	schema := Object().Keys(K{
+		"title": String().Min(3).Required(),
+		"author": Object().Keys(K{
+			"name":  String().Required(),
+			"email": String(),
+		}),
+		"tags": Array().Items(String()),
+	})
+	document := map[string]interface{}{
+		"title": "Goodbye!",
+		"author": map[string]interface{}{
+			"name":  "faceair",
+			"email": "faceair@example.com",
+		},
+		"tags": []interface{}{"example", "sample"},
+	}
+
+	dataMap, err := ApplyMergePatch(document, []byte(`{
+		"title": "Hello!",
+		"author": {"email": null},
+		"tags": ["example"]
+	}`), schema)
+	if err != nil {
+		t.Error("should no error", err)
+	}
+	if dataMap["title"] != "Hello!" || len(dataMap["tags"].([]interface{})) != 1 {
+		t.Error("should apply patch")
+	}
+	if _, ok := dataMap["author"].(map[string]interface{})["email"]; ok {
+		t.Error("null should delete key")
+	}
+	if _, ok := document["author"].(map[string]interface{})["email"]; !ok || document["title"] != "Goodbye!" {
+		t.Error("should not modify the document")
+	}
+
+	_, err = ApplyMergePatch(document, []byte(`{"title": "Hi", "author": {"name": null}}`), schema)
+	bag, ok := err.(*ErrorBag)
+	if !ok || len(bag.StringArray()) != 2 {
+		t.Error("should return error bag", err)
+	}
+
+	if _, err = ApplyMergePatch(document, []byte(`[]`), schema); err == nil {
+		t.Error("patch should be an object")
+	}
+
+	if _, err = ApplyMergePatch(document, []byte(`{`), schema); err == nil {
+		t.Error("invalid json should error")
+	}
+}
+
+func TestApplyJSONPatch(t *testing.T) {
+	schema := Object().Keys(K{
+		"id":    Number().Required(),
+		"title": String().Min(3).Required(),
+		"tags":  Array().Items(String()).Max(3),
+		"meta":  Object(),
+	})
+	document := map[string]interface{}{
+		"id":    1.0,
+		"title": "Hello",
+		"tags":  []interface{}{"a", "b"},
+		"meta":  map[string]interface{}{"a/b": "c"},
+	}
+
+	dataMap, err := ApplyJSONPatch(document, []byte(`[
+		{"op": "test", "path": "/title", "value": "Hello"},
+		{"op": "replace", "path": "/title", "value": "World"},
+		{"op": "add", "path": "/tags/0", "value": "z"},
+		{"op": "remove", "path": "/tags/2"},
+		{"op": "copy", "from": "/tags/0", "path": "/tags/-"},
+		{"op": "move", "from": "/meta/a~1b", "path": "/meta/d"}
+	]`), schema)
+	if err != nil {
+		t.Error("should no error", err)
+	}
+	if dataMap["title"] != "World" || !reflect.DeepEqual(dataMap["tags"], []interface{}{"z", "a", "z"}) {
+		t.Error("should apply patch", dataMap)
+	}
+	if !reflect.DeepEqual(dataMap["meta"], map[string]interface{}{"d": "c"}) {
+		t.Error("should move value", dataMap["meta"])
+	}
+	if document["title"] != "Hello" || len(document["tags"].([]interface{})) != 2 {
+		t.Error("should not modify the document")
+	}
+
+	_, err = ApplyJSONPatch(document, []byte(`[
+		{"op": "update", "path": "/title"},
+		{"op": "add", "path": "title", "value": 1},
+		{"op": "add", "path": "/title"},
+		{"op": "move", "path": "/title"}
+	]`), schema)
+	bag, ok := err.(*ErrorBag)
+	if !ok || len(bag.StringArray()) != 4 {
+		t.Error("should check operation structure", err)
+	}
+
+	if _, err = ApplyJSONPatch(document, []byte(`[{"op": "remove", "path": "/tags/5"}]`), schema); err == nil {
+		t.Error("nonexistent path should error")
+	}
+	if _, err = ApplyJSONPatch(document, []byte(`[{"op": "test", "path": "/id", "value": 2}]`), schema); err == nil {
+		t.Error("test operation should error")
+	}
+	if _, err = ApplyJSONPatch(document, []byte(`[{"op": "move", "from": "/meta", "path": "/meta/x"}]`), schema); err == nil {
+		t.Error("move into child should error")
+	}
+	if _, err = ApplyJSONPatch(document, []byte(`[{"op": "replace", "path": "/title", "value": "Hi"}]`), schema); err == nil {
+		t.Error("patched document should be validated")
+	}
+	if _, err = ApplyJSONPatch(document, []byte(`[{"op": "replace", "path": "", "value": []}]`), schema); err == nil {
+		t.Error("patched document should be an object")
+	}
+}
+
+func TestAllowedPatchPaths(t *testing.T) {
+	schema := Object().Keys(K{
+		"id":    Number().Required(),
+		"title": String(),
+		"tags":  Array().Items(String()),
+	})
+	document := map[string]interface{}{"id": 1.0, "title": "Hello", "tags": []interface{}{"a"}}
+	allowed := AllowedPatchPaths("/title", "/tags/*")
+
+	if _, err := ApplyJSONPatch(document, []byte(`[
+		{"op": "test", "path": "/id", "value": 1},
+		{"op": "replace", "path": "/title", "value": "World"},
+		{"op": "add", "path": "/tags/-", "value": "b"}
+	]`), schema, allowed); err != nil {
+		t.Error("should no error", err)
+	}
+
+	_, err := ApplyJSONPatch(document, []byte(`[
+		{"op": "replace", "path": "/id", "value": 2},
+		{"op": "replace", "path": "/tags", "value": []},
+		{"op": "move", "from": "/id", "path": "/title"}
+	]`), schema, allowed)
+	bag, ok := err.(*ErrorBag)
+	if !ok || len(bag.StringArray()) != 3 {
+		t.Error("should forbid paths", err)
+	}
+}
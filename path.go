@@ -0,0 +1,73 @@
+package jio
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// PathExtractor returns the path parameters of the request, so ValidatePath works with any router.
+// For example with chi:
+//
+//	func(r *http.Request) map[string]string {
+//		params := make(map[string]string)
+//		rctx := chi.RouteContext(r.Context())
+//		for i, key := range rctx.URLParams.Keys {
+//			params[key] = rctx.URLParams.Values[i]
+//		}
+//		return params
+//	}
+type PathExtractor func(*http.Request) map[string]string
+
+func (extractor PathExtractor) values(r *http.Request) url.Values {
+	params := make(url.Values)
+	for key, value := range extractor(r) {
+		params.Set(key, value)
+	}
+	return params
+}
+
+// PathPattern returns a PathExtractor matching the pattern against the escaped path of the request,
+// each segment is unescaped after the split, so `/orders/a%2Fb` extracts {"id": "a/b"} with `/orders/{id}`.
+// Segments like `{id}` capture a single segment and a final segment like `{path...}` captures the rest of the path,
+// for example the pattern `/orders/{id}` extracts {"id": "42"} from `/orders/42`.
+// No parameter is extracted when the path does not match the pattern.
+func PathPattern(pattern string) PathExtractor {
+	patternSegments := strings.Split(strings.Trim(pattern, "/"), "/")
+	return func(r *http.Request) map[string]string {
+		params := make(map[string]string)
+		segments := strings.Split(strings.Trim(r.URL.EscapedPath(), "/"), "/")
+		for i, patternSegment := range patternSegments {
+			isParam := strings.HasPrefix(patternSegment, "{") && strings.HasSuffix(patternSegment, "}")
+			name := strings.TrimSuffix(strings.TrimPrefix(patternSegment, "{"), "}")
+			if isParam && strings.HasSuffix(name, "...") && i == len(patternSegments)-1 {
+				if i < len(segments) {
+					rest, err := url.PathUnescape(strings.Join(segments[i:], "/"))
+					if err != nil {
+						return map[string]string{}
+					}
+					params[strings.TrimSuffix(name, "...")] = rest
+				}
+				return params
+			}
+			if i >= len(segments) {
+				return map[string]string{}
+			}
+			segment, err := url.PathUnescape(segments[i])
+			if err != nil {
+				return map[string]string{}
+			}
+			if !isParam {
+				if patternSegment != segment {
+					return map[string]string{}
+				}
+				continue
+			}
+			params[name] = segment
+		}
+		if len(segments) != len(patternSegments) {
+			return map[string]string{}
+		}
+		return params
+	}
+}
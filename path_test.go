@@ -0,0 +1,68 @@
+package jio
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestPathPattern(t *testing.T) {
+	cases := []struct {
+		pattern string
+		path    string
+		params  map[string]string
+	}{
+		{"/orders/{id}", "/orders/42", map[string]string{"id": "42"}},
+		{"/orders/{id}/items/{item}", "/orders/42/items/7/", map[string]string{"id": "42", "item": "7"}},
+		{"/files/{path...}", "/files/a/b.txt", map[string]string{"path": "a/b.txt"}},
+		{"/orders/{id}", "/orders/42/items", map[string]string{}},
+		{"/orders/{id}", "/users/42", map[string]string{}},
+		{"/orders/{id}/items", "/orders/42", map[string]string{}},
+		{"/orders/{id}", "/orders/a%2Fb", map[string]string{"id": "a/b"}},
+		{"/orders/{id}/items", "/orders/a%20b/items", map[string]string{"id": "a b"}},
+		{"/files/{path...}", "/files/a%2Fb/c%20d.txt", map[string]string{"path": "a/b/c d.txt"}},
+	}
+	for _, c := range cases {
+		r := httptest.NewRequest(http.MethodGet, c.path, nil)
+		if params := PathPattern(c.pattern)(r); !reflect.DeepEqual(params, c.params) {
+			t.Error("pattern test failed", c.pattern, c.path, params)
+		}
+	}
+}
+
+func TestValidatePath(t *testing.T) {
+	schema := Object().Keys(K{
+		"id": Number().Integer().Min(1).Required(),
+	})
+	handler := ValidatePath(schema, PathPattern("/orders/{id}"), DefaultErrorHandler)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		params := r.Context().Value(ContextKeyParams).(map[string]interface{})
+		fmt.Fprint(w, params["id"])
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/orders/42", nil))
+	if w.Body.String() != "42" {
+		t.Error("not ok", w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/orders/abc", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Error("should bad request")
+	}
+
+	custom := func(r *http.Request) map[string]string {
+		return map[string]string{"id": r.Header.Get("X-Id")}
+	}
+	w = httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("X-Id", "7")
+	ValidatePath(schema, custom, DefaultErrorHandler)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "ok")
+	})).ServeHTTP(w, r)
+	if w.Body.String() != "ok" {
+		t.Error("custom extractor test failed", w.Body.String())
+	}
+}
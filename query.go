@@ -0,0 +1,111 @@
+package jio
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// NestedQuery parse bracket and dot notation in query keys into nested objects,
+// for example `filter[status]=open&filter.owner=me` becomes {"filter": {"status": "open", "owner": "me"}}.
+// A key ending with `[]` always becomes an array.
+func NestedQuery() Option {
+	return func(o *options) {
+		o.nestedQuery = true
+	}
+}
+
+// parseValues convert the query values into the map validated by the schema.
+// Repeated keys become arrays when the schema of the key is an ArraySchema,
+// otherwise only the first value is used. Each repeated value is parsed by ArraySchema.ParseString
+// or ParseJSON when set, and the items are concatenated.
+func parseValues(values url.Values, schema Schema, o options) map[string]interface{} {
+	query := make(map[string]interface{}, len(values))
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fields, forceArray := []string{key}, false
+		if o.nestedQuery {
+			fields, forceArray = queryFields(key)
+		}
+		setQueryValue(query, fields, values[key], schema, forceArray)
+	}
+	return query
+}
+
+func setQueryValue(query map[string]interface{}, fields []string, values []string, schema Schema, forceArray bool) {
+	for _, field := range fields[:len(fields)-1] {
+		schema = childSchema(schema, field)
+		child, ok := query[field].(map[string]interface{})
+		if !ok {
+			child = make(map[string]interface{})
+			query[field] = child
+		}
+		query = child
+	}
+	field := fields[len(fields)-1]
+	schema = childSchema(schema, field)
+	array, isArray := unwrapSchema(schema).(*ArraySchema)
+	switch {
+	case forceArray || isArray && (len(values) > 1 || array.split == nil && !array.json):
+		items, _ := query[field].([]interface{})
+		for _, value := range values {
+			if isArray && (array.split != nil || array.json) {
+				if parsed, ok := array.parseString(value); ok {
+					items = append(items, parsed.([]interface{})...)
+					continue
+				}
+			}
+			items = append(items, value)
+		}
+		query[field] = items
+	default:
+		query[field] = values[0]
+	}
+}
+
+// queryFields split a key like `a[b][]` or `a.b` into its fields.
+func queryFields(key string) (fields []string, forceArray bool) {
+	if strings.HasSuffix(key, "[]") {
+		key, forceArray = key[:len(key)-2], true
+	}
+	key = strings.Replace(key, "]", "", -1)
+	key = strings.Replace(key, "[", ".", -1)
+	for _, field := range strings.Split(key, ".") {
+		if field != "" {
+			fields = append(fields, field)
+		}
+	}
+	if len(fields) == 0 {
+		fields = []string{key}
+	}
+	return
+}
+
+// childSchema return the schema of the key if schema is an ObjectSchema.
+func childSchema(schema Schema, key string) Schema {
+	object, ok := unwrapSchema(schema).(*ObjectSchema)
+	if !ok || object.children == nil {
+		return nil
+	}
+	return (*object.children)[key]
+}
+
+// unwrapSchema return the schema wrapped by ObjectSchema.Partial, ObjectSchema.RequiredAll or ValidateRequest.
+func unwrapSchema(schema Schema) Schema {
+	for {
+		switch s := schema.(type) {
+		case *optionalSchema:
+			schema = s.Schema
+		case *requiredSchema:
+			schema = s.Schema
+		case *coerceSchema:
+			schema = s.Schema
+		default:
+			return schema
+		}
+	}
+}
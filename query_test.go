@@ -0,0 +1,64 @@
+package jio
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestParseValues(t *testing.T) {
+	schema := Object().Keys(K{
+		"tag":  Array().Items(String()),
+		"ids":  Array().ParseString(",").Items(Number().ParseString()),
+		"name": String(),
+	})
+	values, _ := url.ParseQuery("tag=a&tag=b&ids=1,2&name=x&name=y&other=1&other=2")
+	query := parseValues(values, schema, options{})
+	expected := map[string]interface{}{
+		"tag":   []interface{}{"a", "b"},
+		"ids":   "1,2",
+		"name":  "x",
+		"other": "1",
+	}
+	if !reflect.DeepEqual(query, expected) {
+		t.Error("repeated keys test failed", query)
+	}
+
+	values, _ = url.ParseQuery("ids=1,2&ids=3")
+	query = parseValues(values, schema, options{})
+	if !reflect.DeepEqual(query["ids"], []interface{}{"1", "2", "3"}) {
+		t.Error("repeated values should be parsed and concatenated", query)
+	}
+	ctx := NewContext(query, Coerce(true))
+	Object().Keys(K{"ids": Array().ParseString(",").Items(Number().Integer())}).Validate(ctx)
+	if !ctx.ErrorBag.Empty() {
+		t.Error("repeated values should be validated as numbers", ctx.ErrorBag)
+	}
+
+	values, _ = url.ParseQuery("tag=a")
+	query = parseValues(values, schema, options{})
+	if !reflect.DeepEqual(query["tag"], []interface{}{"a"}) {
+		t.Error("single value should become an array", query)
+	}
+}
+
+func TestParseValues_Nested(t *testing.T) {
+	schema := Object().Keys(K{
+		"filter": Object().Keys(K{
+			"status": Array().Items(String()),
+		}),
+	})
+	values, _ := url.ParseQuery("filter[status]=open&filter[status]=closed&filter.owner=me&sort[]=name&a[b][c]=d")
+	query := parseValues(values, schema, newOptions([]Option{NestedQuery()}))
+	expected := map[string]interface{}{
+		"filter": map[string]interface{}{
+			"status": []interface{}{"open", "closed"},
+			"owner":  "me",
+		},
+		"sort": []interface{}{"name"},
+		"a":    map[string]interface{}{"b": map[string]interface{}{"c": "d"}},
+	}
+	if !reflect.DeepEqual(query, expected) {
+		t.Error("nested query test failed", query)
+	}
+}
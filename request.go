@@ -0,0 +1,143 @@
+package jio
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// Sections of the request validated by ValidateRequest, and the context keys their values are saved with.
+var requestSections = map[string]contextKey{
+	"body":    ContextKeyBody,
+	"query":   ContextKeyQuery,
+	"headers": ContextKeyHeader,
+	"cookies": ContextKeyCookie,
+	"params":  ContextKeyParams,
+}
+
+// ValidateRequest validate the whole request with a single schema, whose top-level keys are the sections
+// `body`, `query`, `headers`, `cookies` and `params`. Only the sections declared in the schema are read.
+// All sections are validated in one Context, so rules can Ref across sections like `query.limit`,
+// and all errors are returned to the errorHandler in a single ErrorBag.
+// The validated value of each section is saved to the request context with the same key as the
+// dedicated middleware, for example ContextKeyQuery for `query`.
+//
+// The body is decoded from JSON, or from the form values like ValidateForm.
+// Query, headers, cookies, params and form bodies are converted as described by Coerce.
+// Path params are read with the extractor, which may be nil when the schema has no `params` key.
+func ValidateRequest(schema *ObjectSchema, extractor PathExtractor, errorHandler func(http.ResponseWriter, *http.Request, error), opts ...Option) func(next http.Handler) http.Handler {
+	stringSections := []string{"query", "headers", "cookies", "params"}
+	jsonSchema := schema.wrapKeys(stringSections, coerceStrings)
+	formSchema := schema.wrapKeys(append(stringSections, "body"), coerceStrings)
+	o := newOptions(append([]Option{Coerce(true)}, opts...))
+
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			r, opts := requestWarnings(r, opts)
+			data := make(map[string]interface{})
+			if section := childSchema(schema, "query"); section != nil {
+				data["query"] = parseValues(r.URL.Query(), section, o)
+			}
+			if section := childSchema(schema, "headers"); section != nil {
+				data["headers"] = parseValues(headerValues(r), section, o)
+			}
+			if section := childSchema(schema, "cookies"); section != nil {
+				data["cookies"] = parseValues(cookieValues(r), section, o)
+			}
+			if section := childSchema(schema, "params"); section != nil && extractor != nil {
+				data["params"] = parseValues(extractor.values(r), section, o)
+			}
+
+			requestSchema, isJSON := jsonSchema, false
+			var rawBody []byte
+			if section := childSchema(schema, "body"); section != nil {
+				contentType := r.Header.Get("Content-Type")
+				switch {
+				case strings.Contains(contentType, "application/json"):
+					body, err := readBody(w, r, o)
+					if err != nil {
+						errorHandler(w, r, err)
+						return
+					}
+					if len(bytes.TrimSpace(body)) > 0 {
+						value, err := decodeJSON(body, o, "body")
+						if err != nil {
+							errorHandler(w, r, err)
+							return
+						}
+						data["body"] = value
+					}
+					rawBody = body
+					isJSON = true
+				case strings.Contains(contentType, "application/x-www-form-urlencoded"), strings.Contains(contentType, "multipart/form-data"):
+					limitBody(w, r, o)
+					values, err := parseForm(r)
+					if err != nil {
+						errorHandler(w, r, bodyLimitError(err, o))
+						return
+					}
+					if r.MultipartForm != nil {
+						// net/http only removes the temporary files of the original request, r may be a copy.
+						defer r.MultipartForm.RemoveAll()
+					}
+					form := parseValues(values, section, o)
+					if r.MultipartForm != nil {
+						for key, files := range r.MultipartForm.File {
+							form[key] = files
+						}
+					}
+					data["body"] = form
+					requestSchema = formSchema
+				}
+			}
+
+			ctx := NewContext(data, opts...)
+			requestSchema.Validate(ctx)
+			if !ctx.ErrorBag.Empty() {
+				errorHandler(w, r, ctx.ErrorBag)
+				return
+			}
+
+			values, _ := ctx.Value.(map[string]interface{})
+			requestCtx := r.Context()
+			for section, key := range requestSections {
+				if value, ok := values[section]; ok {
+					requestCtx = context.WithValue(requestCtx, key, value)
+				}
+			}
+			if body, ok := values["body"]; ok && isJSON {
+				dataNew, err := encodeJSON(rawBody, body, o)
+				if err != nil {
+					errorHandler(w, r, err)
+					return
+				}
+				r.Body = ioutil.NopCloser(bytes.NewBuffer(dataNew))
+			}
+			next.ServeHTTP(w, r.WithContext(requestCtx))
+		}
+		return http.HandlerFunc(fn)
+	}
+}
+
+func coerceStrings(schema Schema) Schema {
+	return &coerceSchema{schema}
+}
+
+// coerceSchema validate the wrapped schema with Coerce enabled.
+type coerceSchema struct {
+	Schema
+}
+
+func (s *coerceSchema) Validate(ctx *Context) {
+	coerce := ctx.options.coerce
+	ctx.options.coerce = true
+	s.Schema.Validate(ctx)
+	ctx.options.coerce = coerce
+}
+
+func (s *coerceSchema) stripped() bool {
+	strip, ok := s.Schema.(strippable)
+	return ok && strip.stripped()
+}
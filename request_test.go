@@ -0,0 +1,79 @@
+package jio
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestValidateRequest(t *testing.T) {
+	schema := Object().Keys(K{
+		"params": Object().Keys(K{
+			"id": Number().Integer().Required(),
+		}).Required(),
+		"query": Object().Keys(K{
+			"limit": Number().Integer().Max(100),
+		}).SetPriority(1),
+		"headers": Object().Keys(K{
+			"X-Request-Id": String().Required(),
+		}),
+		"body": Object().Keys(K{
+			"count": Number().Transform(func(ctx *Context) {
+				limit, ok := ctx.Ref("query.limit")
+				if ok && ctx.Value.(float64) > limit.(float64) {
+					ctx.Abort(ErrorMax(ctx, "query.limit"))
+				}
+			}).Required(),
+		}).Required(),
+	})
+	handler := ValidateRequest(schema, PathPattern("/orders/{id}"), DefaultErrorHandler)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		params := r.Context().Value(ContextKeyParams).(map[string]interface{})
+		query := r.Context().Value(ContextKeyQuery).(map[string]interface{})
+		header := r.Context().Value(ContextKeyHeader).(map[string]interface{})
+		fmt.Fprint(w, params["id"], query["limit"], header["X-Request-Id"], string(body))
+	}))
+
+	request := func(path, body string) *http.Request {
+		r := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		r.Header.Set("Content-Type", "application/json")
+		r.Header.Set("X-Request-Id", "abc")
+		return r
+	}
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, request("/orders/42?limit=10", `{"count": 5}`))
+	if w.Body.String() != `42 10abc{"count":5}` {
+		t.Error("not ok", w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, request("/orders/x?limit=10", `{"count": 50}`))
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "body.count must be") || !strings.Contains(w.Body.String(), "params.id must be a number") {
+		t.Error("should report the errors of all sections", w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, request("/orders/42", `{"count": "5"}`))
+	if w.Code != http.StatusBadRequest {
+		t.Error("json body should not be converted")
+	}
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, request("/orders/42", `{`))
+	if w.Code != http.StatusBadRequest {
+		t.Error("invalid json should bad request")
+	}
+
+	w = httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/orders/42", strings.NewReader("count=5"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	r.Header.Set("X-Request-Id", "abc")
+	handler.ServeHTTP(w, r)
+	if w.Code != http.StatusOK {
+		t.Error("form body should be converted", w.Body.String())
+	}
+}
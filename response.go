@@ -0,0 +1,94 @@
+package jio
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+)
+
+// ResponseMode decides what ValidateResponse does with a response which fails the validation.
+type ResponseMode int
+
+const (
+	// ResponseLog log the errors with the standard logger and send the response unchanged.
+	ResponseLog ResponseMode = iota
+	// ResponseWarn add the errors to the ResponseWarningHeader header of the response.
+	ResponseWarn
+	// ResponseReject replace the response with a 500 Internal Server Error.
+	ResponseReject
+)
+
+// ResponseWarningHeader is the header used by ResponseWarn.
+var ResponseWarningHeader = "X-Response-Validation-Error"
+
+// ValidateResponse validate the JSON responses of the handler using the schema of the response status code.
+// The schema with the status code 0 is used for status codes without a schema,
+// and responses without a body, without a schema or with a Content-Type other than application/json are sent unchanged.
+// The response is buffered until the handler returns, so it is intended for development and contract tests.
+func ValidateResponse(schemas map[int]Schema, mode ResponseMode) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			rw := &responseBuffer{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(rw, r)
+
+			err := validateResponse(rw, schemas)
+			if err != nil {
+				switch mode {
+				case ResponseLog:
+					log.Printf("jio: %s %s response %d: %s", r.Method, r.URL.Path, rw.status, err)
+				case ResponseWarn:
+					w.Header().Set(ResponseWarningHeader, err.Error())
+				case ResponseReject:
+					body, _ := json.Marshal(map[string]string{
+						"message": err.Error(),
+					})
+					w.Header().Del("Content-Length")
+					w.Header().Set("Content-Type", "application/json; charset=utf-8")
+					w.WriteHeader(http.StatusInternalServerError)
+					w.Write(body)
+					return
+				}
+			}
+			w.WriteHeader(rw.status)
+			w.Write(rw.body.Bytes())
+		}
+		return http.HandlerFunc(fn)
+	}
+}
+
+func validateResponse(rw *responseBuffer, schemas map[int]Schema) error {
+	schema, ok := schemas[rw.status]
+	if !ok {
+		schema, ok = schemas[0]
+	}
+	if !ok || rw.body.Len() == 0 || !strings.Contains(rw.Header().Get("Content-Type"), "application/json") {
+		return nil
+	}
+	var data interface{}
+	if err := json.Unmarshal(rw.body.Bytes(), &data); err != nil {
+		return err
+	}
+	ctx := NewContext(data)
+	schema.Validate(ctx)
+	if !ctx.ErrorBag.Empty() {
+		return ctx.ErrorBag
+	}
+	return nil
+}
+
+// responseBuffer keep the status code and body written by the handler.
+type responseBuffer struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (rw *responseBuffer) WriteHeader(status int) {
+	rw.status = status
+}
+
+func (rw *responseBuffer) Write(b []byte) (int, error) {
+	return rw.body.Write(b)
+}
@@ -0,0 +1,70 @@
+package jio
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestValidateResponse(t *testing.T) {
+	schemas := map[int]Schema{
+		http.StatusOK: Object().Keys(K{
+			"id":   Number().Integer().Required(),
+			"name": String().Required(),
+		}),
+		0: Object().Keys(K{
+			"message": String().Required(),
+		}),
+	}
+	handler := func(status int, contentType, body string) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", contentType)
+			w.WriteHeader(status)
+			fmt.Fprint(w, body)
+		})
+	}
+
+	w := httptest.NewRecorder()
+	ValidateResponse(schemas, ResponseReject)(handler(http.StatusOK, "application/json", `{"id": 1, "name": "a"}`)).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	if w.Code != http.StatusOK || w.Body.String() != `{"id": 1, "name": "a"}` {
+		t.Error("valid response should be unchanged", w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	ValidateResponse(schemas, ResponseReject)(handler(http.StatusOK, "application/json", `{"id": 1.5}`)).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	if w.Code != http.StatusInternalServerError || !strings.Contains(w.Body.String(), "name is required") {
+		t.Error("invalid response should be rejected", w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	ValidateResponse(schemas, ResponseWarn)(handler(http.StatusNotFound, "application/json", `{}`)).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	if w.Code != http.StatusNotFound || w.Header().Get(ResponseWarningHeader) != "[message is required]" {
+		t.Error("fallback schema should add warning header", w.Code, w.Header())
+	}
+
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	defer log.SetOutput(os.Stderr)
+	w = httptest.NewRecorder()
+	ValidateResponse(schemas, ResponseLog)(handler(http.StatusOK, "application/json", `[]`)).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users", nil))
+	if w.Code != http.StatusOK || w.Body.String() != `[]` || !strings.Contains(logs.String(), "GET /users response 200: [ must be an object]") {
+		t.Error("invalid response should be logged", logs.String())
+	}
+
+	w = httptest.NewRecorder()
+	ValidateResponse(schemas, ResponseReject)(handler(http.StatusNoContent, "application/json", ``)).ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/", nil))
+	if w.Code != http.StatusNoContent || w.Body.Len() != 0 {
+		t.Error("response without body should be unchanged", w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	ValidateResponse(schemas, ResponseReject)(handler(http.StatusOK, "text/plain", `hello`)).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	if w.Code != http.StatusOK || w.Body.String() != "hello" {
+		t.Error("non json response should be unchanged")
+	}
+}
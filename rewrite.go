@@ -0,0 +1,273 @@
+package jio
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+)
+
+// encodeJSON encode the validated value, patching the original document with PreserveFormat.
+func encodeJSON(original []byte, value interface{}, o options) ([]byte, error) {
+	if !o.preserveFormat {
+		return json.Marshal(value)
+	}
+	p := &jsonParser{data: original}
+	p.space()
+	node, ok := p.value()
+	if p.space(); !ok || p.pos != len(original) {
+		return json.Marshal(value)
+	}
+	var buf bytes.Buffer
+	buf.Write(original[:node.start])
+	if err := node.render(&buf, original, value, o); err != nil {
+		return nil, err
+	}
+	buf.Write(original[node.end:])
+	return buf.Bytes(), nil
+}
+
+// jsonNode is the position of a value in the original document.
+type jsonNode struct {
+	start, end int
+	kind       byte // '{', '[' or 0 for the other values
+	members    []jsonMember
+}
+
+// jsonMember is an object member or an array item, which has no key.
+// It starts right after the preceding '{', '[' or ',' and ends at the following delimiter.
+type jsonMember struct {
+	start, end       int
+	keyStart, keyEnd int
+	key              string
+	value            *jsonNode
+}
+
+// render write the value, copying the original bytes of the parts which are unchanged.
+func (n *jsonNode) render(buf *bytes.Buffer, data []byte, value interface{}, o options) error {
+	switch n.kind {
+	case '{':
+		if object, ok := value.(map[string]interface{}); ok {
+			return n.renderObject(buf, data, object, o)
+		}
+	case '[':
+		if items, ok := value.([]interface{}); ok && len(items) == len(n.members) {
+			return n.renderArray(buf, data, items, o)
+		}
+	default:
+		var original interface{}
+		if err := decodeScalar(data[n.start:n.end], &original, o); err == nil && reflect.DeepEqual(original, value) {
+			buf.Write(data[n.start:n.end])
+			return nil
+		}
+	}
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	buf.Write(raw)
+	return nil
+}
+
+// renderObject keep the order and the formatting of the original keys,
+// drop the removed keys and append the new keys in alphabetical order.
+func (n *jsonNode) renderObject(buf *bytes.Buffer, data []byte, object map[string]interface{}, o options) error {
+	last := make(map[string]int, len(n.members))
+	for i, m := range n.members {
+		last[m.key] = i
+	}
+	closing := data[n.start+1 : n.end-1]
+	lead, sep := []byte{}, []byte(":")
+	if len(n.members) > 0 {
+		m := n.members[len(n.members)-1]
+		closing = data[m.value.end:m.end]
+		lead, sep = data[m.start:m.keyStart], data[m.keyEnd:m.value.start]
+	}
+
+	buf.WriteByte('{')
+	var written int
+	var trail []byte
+	for i, m := range n.members {
+		value, ok := object[m.key]
+		if !ok || last[m.key] != i {
+			continue
+		}
+		if written > 0 {
+			buf.Write(trail)
+			buf.WriteByte(',')
+		}
+		buf.Write(data[m.start:m.value.start])
+		if err := m.value.render(buf, data, value, o); err != nil {
+			return err
+		}
+		trail = nil
+		if i < len(n.members)-1 {
+			trail = data[m.value.end:m.end]
+		}
+		written++
+	}
+
+	var added []string
+	for key := range object {
+		if _, ok := last[key]; !ok {
+			added = append(added, key)
+		}
+	}
+	sort.Strings(added)
+	for _, key := range added {
+		if written > 0 {
+			buf.Write(trail)
+			buf.WriteByte(',')
+		}
+		rawKey, err := json.Marshal(key)
+		if err != nil {
+			return err
+		}
+		rawValue, err := json.Marshal(object[key])
+		if err != nil {
+			return err
+		}
+		buf.Write(lead)
+		buf.Write(rawKey)
+		buf.Write(sep)
+		buf.Write(rawValue)
+		trail = nil
+		written++
+	}
+	buf.Write(closing)
+	buf.WriteByte('}')
+	return nil
+}
+
+// renderArray render the items in place, the arrays whose length changed are encoded again.
+func (n *jsonNode) renderArray(buf *bytes.Buffer, data []byte, items []interface{}, o options) error {
+	offset := n.start
+	for i, m := range n.members {
+		buf.Write(data[offset:m.value.start])
+		if err := m.value.render(buf, data, items[i], o); err != nil {
+			return err
+		}
+		offset = m.value.end
+	}
+	buf.Write(data[offset:n.end])
+	return nil
+}
+
+// decodeScalar decode a json string, number, boolean or null like decodeJSON.
+func decodeScalar(raw []byte, value *interface{}, o options) error {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	if o.useNumber {
+		dec.UseNumber()
+	}
+	return dec.Decode(value)
+}
+
+// jsonParser find the position of the values of a valid json document.
+type jsonParser struct {
+	data []byte
+	pos  int
+}
+
+func (p *jsonParser) peek() byte {
+	if p.pos >= len(p.data) {
+		return 0
+	}
+	return p.data[p.pos]
+}
+
+func (p *jsonParser) space() {
+	for p.pos < len(p.data) {
+		switch p.data[p.pos] {
+		case ' ', '\t', '\r', '\n':
+			p.pos++
+		default:
+			return
+		}
+	}
+}
+
+func (p *jsonParser) value() (*jsonNode, bool) {
+	n := &jsonNode{start: p.pos}
+	switch c := p.peek(); c {
+	case 0:
+		return nil, false
+	case '{', '[':
+		n.kind = c
+		closing := byte('}')
+		if c == '[' {
+			closing = ']'
+		}
+		p.pos++
+		p.space()
+		if p.peek() == closing {
+			p.pos++
+			n.end = p.pos
+			return n, true
+		}
+		p.pos = n.start + 1
+		for {
+			m := jsonMember{start: p.pos}
+			p.space()
+			if c == '{' {
+				m.keyStart = p.pos
+				if !p.string() {
+					return nil, false
+				}
+				m.keyEnd = p.pos
+				if err := json.Unmarshal(p.data[m.keyStart:m.keyEnd], &m.key); err != nil {
+					return nil, false
+				}
+				p.space()
+				if p.peek() != ':' {
+					return nil, false
+				}
+				p.pos++
+				p.space()
+			}
+			value, ok := p.value()
+			if !ok {
+				return nil, false
+			}
+			m.value = value
+			p.space()
+			m.end = p.pos
+			n.members = append(n.members, m)
+			switch p.peek() {
+			case ',':
+				p.pos++
+			case closing:
+				p.pos++
+				n.end = p.pos
+				return n, true
+			default:
+				return nil, false
+			}
+		}
+	case '"':
+		if !p.string() {
+			return nil, false
+		}
+	default:
+		for p.pos < len(p.data) && bytes.IndexByte([]byte(" \t\r\n,:]}"), p.data[p.pos]) < 0 {
+			p.pos++
+		}
+	}
+	n.end = p.pos
+	return n, true
+}
+
+func (p *jsonParser) string() bool {
+	if p.peek() != '"' {
+		return false
+	}
+	for p.pos++; p.pos < len(p.data); p.pos++ {
+		switch p.data[p.pos] {
+		case '\\':
+			p.pos++
+		case '"':
+			p.pos++
+			return true
+		}
+	}
+	return false
+}
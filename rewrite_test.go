@@ -0,0 +1,69 @@
+package jio
+
+import (
+	"testing"
+)
+
+func TestPreserveFormat(t *testing.T) {
+	schema := Object().Keys(K{
+		"name":  String().Trim(),
+		"debug": Bool().Truthy("on"),
+		"price": Number(),
+		"tags":  Array().Items(String().Lowercase()),
+		"items": Array().Dedupe(),
+		"window": Object().Keys(K{
+			"title": String(),
+			"size":  Number().Default(500),
+		}),
+		"secret": String().Strip(),
+		"role":   String().Default("user"),
+	})
+	data := []byte(`{
+    "name": " faceair ",
+    "secret": "x",
+    "price": 1.10,
+    "tags": [ "A", "b" ],
+    "items": [1, 1, 2],
+    "window": {
+        "title": "Sample Widget"
+    },
+    "debug": "on"
+}
+`)
+	_, err := ValidateJSON(&data, schema, PreserveFormat())
+	if err != nil {
+		t.Error(err)
+	}
+	expected := `{
+    "name": "faceair",
+    "price": 1.10,
+    "tags": [ "a", "b" ],
+    "items": [1,2],
+    "window": {
+        "title": "Sample Widget",
+        "size": 500
+    },
+    "debug": true,
+    "role": "user"
+}
+`
+	if string(data) != expected {
+		t.Error("original format should be preserved", string(data))
+	}
+
+	raw := `{ "b": 1,  "a": [ {"x": 1} ], "c": {} }`
+	data = []byte(raw)
+	if _, err = ValidateJSON(&data, Any(), PreserveFormat()); err != nil || string(data) != raw {
+		t.Error("unchanged document should be identical", string(data))
+	}
+
+	data = []byte(`{"b": 1, "a": 2}`)
+	if _, err = ValidateJSON(&data, Any()); err != nil || string(data) != `{"a":2,"b":1}` {
+		t.Error("document should be encoded again by default", string(data))
+	}
+
+	data = []byte(`{"a": "x", "b": 1}`)
+	if _, err = ValidateJSON(&data, Object().Keys(K{"a": String().Strip(), "b": Number()}), PreserveFormat()); err != nil || string(data) != `{ "b": 1}` {
+		t.Error("removed keys should be dropped", string(data))
+	}
+}
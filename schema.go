@@ -14,12 +14,22 @@ func boolPtr(value bool) *bool {
 
 type baseSchema struct {
 	priority int
+	strip    bool
 }
 
 func (b *baseSchema) Priority() int {
 	return b.priority
 }
 
+// strippable is implemented by schemas whose key can be removed from the parent object.
+type strippable interface {
+	stripped() bool
+}
+
+func (b *baseSchema) stripped() bool {
+	return b.strip
+}
+
 func (b *baseSchema) whenEqual(ctx *Context, refPath string, value interface{}, then Schema) {
 	value, ok := ctx.Ref(refPath)
 	if !ok {
@@ -73,6 +73,10 @@ func (s *StringSchema) Default(value string) *StringSchema {
 	s.required = boolPtr(false)
 	return s.PrependTransform(func(ctx *Context) {
 		if ctx.Value == nil {
+			if ctx.options.partial {
+				ctx.Skip()
+				return
+			}
 			ctx.Value = value
 		}
 	})
@@ -95,6 +99,12 @@ func (s *StringSchema) Equal(value string) *StringSchema {
 	})
 }
 
+// Strip same as AnySchema.Strip
+func (s *StringSchema) Strip() *StringSchema {
+	s.strip = true
+	return s
+}
+
 // When same as AnySchema.When
 func (s *StringSchema) When(refPath string, condition interface{}, then Schema) *StringSchema {
 	return s.Transform(func(ctx *Context) { s.whenEqual(ctx, refPath, condition, then) })
@@ -0,0 +1,145 @@
+package jio
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Values is the validated data saved to the request context by the middlewares.
+// The getters find the value by a `.` separated path like Context.Ref
+// and return the zero value and false when it is missing or has another type.
+type Values map[string]interface{}
+
+// Body return the data validated by ValidateBody or ValidateRequest.
+// It is nil when the body is not an object, read r.Context().Value(ContextKeyBody) for the other values.
+func Body(r *http.Request) Values {
+	return contextValues(r, ContextKeyBody)
+}
+
+// Query return the data validated by ValidateQuery or ValidateRequest.
+func Query(r *http.Request) Values {
+	return contextValues(r, ContextKeyQuery)
+}
+
+// Form return the data validated by ValidateForm.
+func Form(r *http.Request) Values {
+	return contextValues(r, ContextKeyForm)
+}
+
+// Header return the data validated by ValidateHeader or ValidateRequest.
+func Header(r *http.Request) Values {
+	return contextValues(r, ContextKeyHeader)
+}
+
+// Cookie return the data validated by ValidateCookie or ValidateRequest.
+func Cookie(r *http.Request) Values {
+	return contextValues(r, ContextKeyCookie)
+}
+
+// Params return the data validated by ValidatePath or ValidateRequest.
+func Params(r *http.Request) Values {
+	return contextValues(r, ContextKeyParams)
+}
+
+// RequestWarnings return the warnings collected by the middlewares for the request,
+// for example the items removed by ArraySchema.DropInvalid. The bag is empty when there is none.
+func RequestWarnings(r *http.Request) *ErrorBag {
+	if bag, ok := r.Context().Value(ContextKeyWarnings).(*ErrorBag); ok {
+		return bag
+	}
+	return NewErrorBag()
+}
+
+func contextValues(r *http.Request, key contextKey) Values {
+	data, _ := r.Context().Value(key).(map[string]interface{})
+	return Values(data)
+}
+
+// Get return the value at the path.
+func (v Values) Get(path string) (interface{}, bool) {
+	return ref(map[string]interface{}(v), strings.Split(path, "."))
+}
+
+// GetString return the string at the path.
+func (v Values) GetString(path string) (string, bool) {
+	value, _ := v.Get(path)
+	s, ok := value.(string)
+	return s, ok
+}
+
+// GetFloat return the number at the path.
+func (v Values) GetFloat(path string) (float64, bool) {
+	value, _ := v.Get(path)
+	switch n := value.(type) {
+	case float64:
+		return n, true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// GetInt return the number at the path if it is an integer.
+func (v Values) GetInt(path string) (int64, bool) {
+	value, _ := v.Get(path)
+	switch n := value.(type) {
+	case float64:
+		if n != math.Trunc(n) || n < math.MinInt64 || n >= math.MaxInt64 {
+			return 0, false
+		}
+		return int64(n), true
+	case int:
+		return int64(n), true
+	case int64:
+		return n, true
+	case json.Number:
+		exact, ok := new(big.Rat).SetString(string(n))
+		if !ok || !exact.IsInt() || !exact.Num().IsInt64() {
+			return 0, false
+		}
+		return exact.Num().Int64(), true
+	}
+	return 0, false
+}
+
+// GetBool return the boolean at the path.
+func (v Values) GetBool(path string) (bool, bool) {
+	value, _ := v.Get(path)
+	b, ok := value.(bool)
+	return b, ok
+}
+
+// GetTime return the time at the path, strings are parsed with the layouts (time.RFC3339 by default).
+func (v Values) GetTime(path string, layouts ...string) (time.Time, bool) {
+	value, _ := v.Get(path)
+	switch t := value.(type) {
+	case time.Time:
+		return t, true
+	case string:
+		if len(layouts) == 0 {
+			layouts = []string{time.RFC3339}
+		}
+		for _, layout := range layouts {
+			if parsed, err := time.Parse(layout, t); err == nil {
+				return parsed, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// GetMap return the object at the path.
+func (v Values) GetMap(path string) (Values, bool) {
+	value, _ := v.Get(path)
+	m, ok := value.(map[string]interface{})
+	return Values(m), ok
+}
@@ -0,0 +1,64 @@
+package jio
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestValues(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	if Body(r) != nil || Query(r) != nil {
+		t.Error("missing values should be nil")
+	}
+	if _, ok := Body(r).GetString("name"); ok {
+		t.Error("getter on nil values should not be ok")
+	}
+
+	r = r.WithContext(context.WithValue(r.Context(), ContextKeyBody, map[string]interface{}{
+		"name":    "faceair",
+		"age":     float64(18),
+		"score":   1.5,
+		"admin":   true,
+		"created": "2018-01-02T03:04:05Z",
+		"profile": map[string]interface{}{
+			"city": "hangzhou",
+		},
+	}))
+	body := Body(r)
+	if s, ok := body.GetString("name"); !ok || s != "faceair" {
+		t.Error("GetString failed")
+	}
+	if _, ok := body.GetString("age"); ok {
+		t.Error("GetString should not be ok on number")
+	}
+	if n, ok := body.GetInt("age"); !ok || n != 18 {
+		t.Error("GetInt failed")
+	}
+	if _, ok := body.GetInt("score"); ok {
+		t.Error("GetInt should not be ok on fraction")
+	}
+	if f, ok := body.GetFloat("score"); !ok || f != 1.5 {
+		t.Error("GetFloat failed")
+	}
+	if b, ok := body.GetBool("admin"); !ok || !b {
+		t.Error("GetBool failed")
+	}
+	if tm, ok := body.GetTime("created"); !ok || !tm.Equal(time.Date(2018, 1, 2, 3, 4, 5, 0, time.UTC)) {
+		t.Error("GetTime failed")
+	}
+	if _, ok := body.GetTime("name"); ok {
+		t.Error("GetTime should not be ok on invalid time")
+	}
+	if s, ok := body.GetString("profile.city"); !ok || s != "hangzhou" {
+		t.Error("GetString by path failed")
+	}
+	if m, ok := body.GetMap("profile"); !ok || m["city"] != "hangzhou" {
+		t.Error("GetMap failed")
+	}
+	if _, ok := body.GetString("profile.country"); ok {
+		t.Error("missing path should not be ok")
+	}
+}